@@ -2,39 +2,37 @@ package builtin
 
 import (
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
-// And buildin function
-func And(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	var result types.Object = types.T
+// CompileAnd compiles an and special form
+func CompileAnd(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	closures := c.CompileArgs(args)
 
-	var err error
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		var result types.Object = types.T
+		var err error
 
-	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
-		result, err = env.Eval(obj, context)
-		if err != nil {
-			return false, err
-		}
+		for _, closure := range closures {
+			result, err = closure(env, context)
+			if err != nil {
+				return nil, err
+			}
 
-		if result == types.NIL {
-			// Signal to stop iteration
-			return true, nil
+			if result == types.NIL {
+				// Stop evaluation
+				break
+			}
 		}
 
-		return false, nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
+		return result, nil
+	}, nil
 }
 
 // CreateBuiltinAnd creates a builtin function object
-func CreateBuiltinAnd() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(And, 0, false)
+func CreateBuiltinAnd() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileAnd, 0, function.Variadic)
 }
@@ -1,12 +1,12 @@
 package builtin
 
 import (
-	"errors"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/arrays"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 	"github.com/almerlucke/glisp/types/numbers"
 )
@@ -34,12 +34,12 @@ func MakeArray(args *cons.Cons, env environment.Environment, context interface{}
 	}
 
 	if n.Type() != types.Number {
-		return nil, errors.New("MAKE-ARRAY expected a number as first argument")
+		return nil, errors.New(errors.TypeError, "MAKE-ARRAY expected a number as first argument")
 	}
 
 	ln := n.(*numbers.Number).Int64Value()
 	if ln < 0 {
-		return nil, errors.New("MAKE-ARRAY expected a positive number as first argument")
+		return nil, errors.New(errors.TypeError, "MAKE-ARRAY expected a positive number as first argument")
 	}
 
 	a := make(arrays.Array, ln)
@@ -52,10 +52,10 @@ func MakeArray(args *cons.Cons, env environment.Environment, context interface{}
 
 // CreateBuiltinMakeArray creates an array function object
 func CreateBuiltinMakeArray() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(MakeArray, 1, true)
+	return functions.NewBuiltinFunction(MakeArray, 1, 2, true)
 }
 
 // CreateBuiltinArray creates an array function object
 func CreateBuiltinArray() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Array, 1, true)
+	return functions.NewBuiltinFunction(Array, 1, function.Variadic, true)
 }
@@ -1,102 +1,116 @@
 package builtin
 
 import (
-	"errors"
-	"fmt"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 	"github.com/almerlucke/glisp/types/symbols"
 )
 
-func symbolAssign(sym *symbols.Symbol, val types.Object, env environment.Environment, context interface{}) (types.Object, error) {
+func compileSymbolAssign(sym *symbols.Symbol, val types.Object, c environment.Compiler) (environment.Closure, error) {
 	if sym.Reserved {
-		return nil, fmt.Errorf("can't assign to a reserved symbol %v", sym)
+		return nil, errors.Errorf(errors.GeneralError, "can't assign to a reserved symbol %v", sym)
 	}
 
-	val, err := env.Eval(val, context)
-	if err != nil {
-		return nil, err
-	}
+	value := c.Compile(val)
+	ref := c.Resolve(sym)
 
-	err = env.SetBinding(sym, val)
-	if err != nil {
-		return nil, err
-	}
-
-	return val, nil
-}
-
-func expressionAssign(c *cons.Cons, val types.Object, env environment.Environment, context interface{}) (types.Object, error) {
-	r, err := env.Eval(c.Car, context)
-	if err != nil {
-		return nil, err
-	}
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		val, err := value(env, context)
+		if err != nil {
+			return nil, err
+		}
 
-	if r.Type() != types.Function {
-		return nil, fmt.Errorf("can't assign to %v", r)
-	}
+		err = ref.Set(env, val)
+		if err != nil {
+			return nil, err
+		}
 
-	assignable, ok := r.(function.Assignable)
-	if !ok {
-		return nil, fmt.Errorf("can't assign to %v", r)
-	}
+		return val, nil
+	}, nil
+}
 
+func compileExpressionAssign(target *cons.Cons, val types.Object, c environment.Compiler) (environment.Closure, error) {
 	// Check for pure and get length
-	pure, length := c.Info()
+	pure, length := target.Info()
 	if !pure {
-		return nil, errors.New("assign can't evaluate a dotted list")
+		return nil, errors.New(errors.GeneralError, "assign can't evaluate a dotted list")
 	}
 
-	// Check if we have enough arguments
-	if (length - 1) < int64(assignable.NumArgs()) {
-		return nil, fmt.Errorf("not enough arguments to function %v", c.Car)
+	var rawArgs *cons.Cons
+	if target.Cdr != types.NIL {
+		rawArgs = target.Cdr.(*cons.Cons)
 	}
 
-	// If we need to first evaluate all args
-	var args *cons.Cons
-	if c.Cdr != types.NIL {
-		args = c.Cdr.(*cons.Cons)
-		if assignable.EvalArgs() {
-			col, serr := args.Map(func(obj types.Object, index interface{}) (types.Object, error) {
-				return env.Eval(obj, context)
-			})
+	head := c.Compile(target.Car)
+	argClosures := c.CompileArgs(rawArgs)
+	value := c.Compile(val)
 
-			if serr != nil {
-				return nil, serr
-			}
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		r, err := head(env, context)
+		if err != nil {
+			return nil, err
+		}
 
-			args = col.(*cons.Cons)
+		if r.Type() != types.Function {
+			return nil, errors.Errorf(errors.TypeError, "can't assign to %v", r)
 		}
-	}
 
-	// Evaluate assign value if needed
-	if assignable.EvalArgs() {
-		val, err = env.Eval(val, context)
+		assignable, ok := r.(function.Assignable)
+		if !ok {
+			return nil, errors.Errorf(errors.TypeError, "can't assign to %v", r)
+		}
+
+		// Check the number of arguments
+		err = functions.CheckArity(target.Car, assignable, int(length-1))
 		if err != nil {
 			return nil, err
 		}
-	}
 
-	return assignable.Assign(args, val, env, context)
+		args := rawArgs
+		val := val
+
+		// If we need to first evaluate all args and the assign value
+		if assignable.EvalArgs() {
+			builder := cons.ListBuilder{}
+
+			for _, closure := range argClosures {
+				obj, err := closure(env, context)
+				if err != nil {
+					return nil, err
+				}
+
+				builder.PushBackObject(obj)
+			}
+
+			args = builder.Head
+
+			val, err = value(env, context)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return assignable.Assign(args, val, env, context)
+	}, nil
 }
 
-// Assign builtin function
-func Assign(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+// CompileAssign compiles an assign special form
+func CompileAssign(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
 	switch args.Car.Type() {
 	case types.Symbol:
-		return symbolAssign(args.Car.(*symbols.Symbol), args.Cdr.(*cons.Cons).Car, env, context)
+		return compileSymbolAssign(args.Car.(*symbols.Symbol), args.Cdr.(*cons.Cons).Car, c)
 	case types.Cons:
-		return expressionAssign(args.Car.(*cons.Cons), args.Cdr.(*cons.Cons).Car, env, context)
+		return compileExpressionAssign(args.Car.(*cons.Cons), args.Cdr.(*cons.Cons).Car, c)
 	}
 
-	return nil, fmt.Errorf("can't assign to %v", args.Car)
+	return nil, errors.Errorf(errors.TypeError, "can't assign to %v", args.Car)
 }
 
 // CreateBuiltinAssign creates a builtin function object
-func CreateBuiltinAssign() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Assign, 2, false)
+func CreateBuiltinAssign() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileAssign, 2, 2)
 }
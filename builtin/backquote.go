@@ -1,79 +1,67 @@
 package builtin
 
 import (
-	"errors"
-
 	"github.com/almerlucke/glisp/globals/symbols"
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
-func expansion(obj types.Object, env environment.Environment, context interface{}) (types.Object, error) {
-	if obj.Type() != types.Cons {
-		// If not a cons just return the object unevaluated
-		return obj, nil
-	}
+// backquoteElement builds a list element, if splice is true the result
+// is appended to the list instead of pushed
+type backquoteElement struct {
+	closure environment.Closure
+	splice  bool
+}
 
-	builder := cons.ListBuilder{}
+func compileExpansion(l *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	elements := []backquoteElement{}
 
-	err := obj.(*cons.Cons).Iter(func(car types.Object, index interface{}) (bool, error) {
-		if car.Type() == types.Cons {
-			l := car.(*cons.Cons)
+	err := l.Iter(func(car types.Object, index interface{}) (bool, error) {
+		if car.Type() != types.Cons {
+			// No expansion needed
+			elements = append(elements, backquoteElement{closure: constant(car)})
+			return false, nil
+		}
 
-			if l.Car == symbols.UnquoteSymbol {
-				// Unquote arg
-				if l.Cdr.Type() != types.Cons {
-					return false, errors.New("UNQUOTE needs one argument")
-				}
+		l := car.(*cons.Cons)
 
-				result, err := env.Eval(l.Cdr.(*cons.Cons).Car, context)
-				if err != nil {
-					return false, err
-				}
+		if l.Car == symbols.UnquoteSymbol {
+			// Unquote arg
+			if l.Cdr.Type() != types.Cons {
+				return false, errors.New(errors.ArityError, "UNQUOTE needs one argument")
+			}
 
-				builder.PushBackObject(result)
-			} else if l.Car == symbols.SpliceSymbol {
-				// Splice arg
-				if l.Cdr.Type() != types.Cons {
-					return false, errors.New("SPLICE needs one argument")
-				}
-
-				result, err := env.Eval(l.Cdr.(*cons.Cons).Car, context)
-				if err != nil {
-					return false, err
-				}
-
-				if result.Type() != types.Cons {
-					return false, errors.New("SPLICE result must be a list")
-				}
-
-				builder.Append(result.(*cons.Cons))
-			} else if l.Car == symbols.BackquoteSymbol {
-				// Recursively call backquote
-				if l.Cdr.Type() != types.Cons {
-					return false, errors.New("BACKQUOTE needs one argument")
-				}
-
-				result, err := Backquote(l.Cdr.(*cons.Cons), env, context)
-				if err != nil {
-					return false, err
-				}
+			elements = append(elements, backquoteElement{closure: c.Compile(l.Cdr.(*cons.Cons).Car)})
+		} else if l.Car == symbols.SpliceSymbol {
+			// Splice arg
+			if l.Cdr.Type() != types.Cons {
+				return false, errors.New(errors.ArityError, "SPLICE needs one argument")
+			}
 
-				builder.PushBackObject(result)
-			} else {
-				// Expand list
-				result, err := expansion(l, env, context)
-				if err != nil {
-					return false, err
-				}
+			elements = append(elements, backquoteElement{closure: c.Compile(l.Cdr.(*cons.Cons).Car), splice: true})
+		} else if l.Car == symbols.BackquoteSymbol {
+			// Recursively compile backquote
+			if l.Cdr.Type() != types.Cons {
+				return false, errors.New(errors.ArityError, "BACKQUOTE needs one argument")
+			}
 
-				builder.PushBackObject(result)
+			closure, err := CompileBackquote(l.Cdr.(*cons.Cons), c)
+			if err != nil {
+				return false, err
 			}
+
+			elements = append(elements, backquoteElement{closure: closure})
 		} else {
-			// No expansion needed
-			builder.PushBackObject(car)
+			// Expand list
+			closure, err := compileExpansion(l, c)
+			if err != nil {
+				return false, err
+			}
+
+			elements = append(elements, backquoteElement{closure: closure})
 		}
 
 		return false, nil
@@ -83,16 +71,39 @@ func expansion(obj types.Object, env environment.Environment, context interface{
 		return nil, err
 	}
 
-	return builder.Head, nil
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		builder := cons.ListBuilder{}
+
+		for _, element := range elements {
+			result, err := element.closure(env, context)
+			if err != nil {
+				return nil, err
+			}
+
+			if !element.splice {
+				builder.PushBackObject(result)
+				continue
+			}
+
+			if result.Type() != types.Cons {
+				return nil, errors.New(errors.TypeError, "SPLICE result must be a list")
+			}
+
+			builder.Append(result.(*cons.Cons))
+		}
+
+		return builder.Head, nil
+	}, nil
 }
 
-// Backquote builtin function
-func Backquote(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+// CompileBackquote compiles a backquote special form, only the unquoted
+// parts of the template are evaluated
+func CompileBackquote(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
 	obj := args.Car
 
 	// If not a list, return object unevaluated
 	if obj.Type() != types.Cons {
-		return obj, nil
+		return constant(obj), nil
 	}
 
 	// Cast to list
@@ -101,26 +112,26 @@ func Backquote(args *cons.Cons, env environment.Environment, context interface{}
 	if l.Car == symbols.UnquoteSymbol {
 		// Unquote arg
 		if l.Cdr.Type() != types.Cons {
-			return nil, errors.New("UNQUOTE needs one argument")
+			return nil, errors.New(errors.ArityError, "UNQUOTE needs one argument")
 		}
 
-		return env.Eval(l.Cdr.(*cons.Cons).Car, context)
+		return c.Compile(l.Cdr.(*cons.Cons).Car), nil
 	} else if l.Car == symbols.SpliceSymbol {
 		// Splice arg outside list context is an error
-		return nil, errors.New("SPLICE can only be evaluated in a list context")
+		return nil, errors.New(errors.GeneralError, "SPLICE can only be evaluated in a list context")
 	} else if l.Car == symbols.BackquoteSymbol {
-		// Recursively call backquote
+		// Recursively compile backquote
 		if l.Cdr.Type() != types.Cons {
-			return nil, errors.New("BACKQUOTE needs one argument")
+			return nil, errors.New(errors.ArityError, "BACKQUOTE needs one argument")
 		}
 
-		return Backquote(l.Cdr.(*cons.Cons), env, context)
+		return CompileBackquote(l.Cdr.(*cons.Cons), c)
 	}
 
-	return expansion(l, env, context)
+	return compileExpansion(l, c)
 }
 
 // CreateBuiltinBackquote creates a builtin function object
-func CreateBuiltinBackquote() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Backquote, 1, false)
+func CreateBuiltinBackquote() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileBackquote, 1, 1)
 }
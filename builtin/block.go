@@ -0,0 +1,106 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+const (
+	blockExit = "BLOCK"
+)
+
+// CompileBlock compiles a block special form, RETURN-FROM exits the
+// innermost lexically enclosing block with the same name
+func CompileBlock(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	name := args.Car
+	if name.Type() != types.Symbol && name.Type() != types.Null {
+		return nil, errors.New(errors.TypeError, "BLOCK expected a symbol as name")
+	}
+
+	bodyCompiler := c.NewScope()
+	ref := bodyCompiler.Resolve(bodyCompiler.Layout().DefineLabel(blockExit, name))
+	body := CompileProgn(args.Cdr, bodyCompiler)
+	layout := bodyCompiler.Layout()
+
+	return func(env environment.Environment, context interface{}) (result types.Object, err error) {
+		// Push a new scope for the label of the block
+		env.PushScope(scope.New(layout, env.CurrentScope()))
+
+		// Make sure we pop the scope after completion
+		defer env.PopScope()
+
+		// The exit point is identified by a new object for every evaluation
+		tag := &symbols.Symbol{Name: blockExit}
+		ref.Bind(env, tag)
+
+		point := &function.ExitPoint{Kind: blockExit, Tag: tag}
+		points := pushExitPoints(env, point)
+
+		defer func() {
+			setExitPoints(env, points)
+
+			if r := recover(); r != nil {
+				result, err = function.Landed(r, point), nil
+			}
+		}()
+
+		return body(env, context)
+	}, nil
+}
+
+// CompileReturnFrom compiles a return-from special form, the name is not
+// evaluated, the optional value is returned from the block
+func CompileReturnFrom(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	name := args.Car
+	if name.Type() != types.Symbol && name.Type() != types.Null {
+		return nil, errors.New(errors.TypeError, "RETURN-FROM expected a symbol as name")
+	}
+
+	label, ok := c.Layout().ResolveLabel(blockExit, name)
+	if !ok {
+		return nil, errors.Errorf(errors.GeneralError, "RETURN-FROM no block named %v", name)
+	}
+
+	ref := c.Resolve(label)
+
+	value := c.Compile(types.NIL)
+	if args.Cdr.Type() == types.Cons {
+		value = c.Compile(args.Cdr.(*cons.Cons).Car)
+	}
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		obj, err := value(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		point, err := labelExitPoint(blockExit, ref, env)
+		if err != nil {
+			return nil, err
+		}
+
+		if point == nil {
+			return nil, errors.Errorf(errors.GeneralError, "RETURN-FROM block %v is no longer active", name)
+		}
+
+		function.ExitTo(point, obj)
+
+		return nil, nil
+	}, nil
+}
+
+// CreateBuiltinBlock creates a builtin function object
+func CreateBuiltinBlock() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileBlock, 1, function.Variadic)
+}
+
+// CreateBuiltinReturnFrom creates a builtin function object
+func CreateBuiltinReturnFrom() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileReturnFrom, 1, 2)
+}
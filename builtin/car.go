@@ -1,11 +1,10 @@
 package builtin
 
 import (
-	"errors"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
@@ -21,10 +20,10 @@ func Car(args *cons.Cons, env environment.Environment, context interface{}) (typ
 		return obj.(*cons.Cons).Car, nil
 	}
 
-	return nil, errors.New("CAR expects a list as argument")
+	return nil, errors.New(errors.TypeError, "CAR expects a list as argument")
 }
 
 // CreateBuiltinCar creates a builtin function object
 func CreateBuiltinCar() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Car, 1, true)
+	return functions.NewBuiltinFunction(Car, 1, 1, true)
 }
@@ -0,0 +1,193 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+
+	globals "github.com/almerlucke/glisp/globals/symbols"
+)
+
+// caseClause is a compiled clause of CASE, ECASE or TYPECASE
+type caseClause struct {
+	keys []types.Object
+	body environment.Closure
+}
+
+// matches checks if one of the keys of the clause is EQL to key
+func (clause *caseClause) matches(key types.Object) bool {
+	for _, k := range clause.keys {
+		if k.Eql(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compileCaseClauses compiles clauses of the form (keys body...), keys is a
+// single key or a list of keys which are not evaluated. If otherwise is
+// true the last clause can have T or OTHERWISE as keys, its body is
+// returned separately. checkKey validates each key
+func compileCaseClauses(name string, clauses types.Object, otherwise bool, checkKey func(types.Object) error, c environment.Compiler) ([]*caseClause, environment.Closure, error) {
+	compiled := []*caseClause{}
+
+	for obj := clauses; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		clause, ok := obj.(*cons.Cons).Car.(*cons.Cons)
+		if !ok {
+			return nil, nil, errors.Errorf(errors.GeneralError, "%v expected clauses of the form (keys body...)", name)
+		}
+
+		var body *cons.Cons
+		if clause.Cdr.Type() == types.Cons {
+			body = clause.Cdr.(*cons.Cons)
+		}
+
+		// Body is in tail position
+		bodyPart := c.CompileBody(body)
+
+		if clause.Car == types.T || clause.Car == globals.OtherwiseSymbol {
+			if !otherwise {
+				return nil, nil, errors.Errorf(errors.GeneralError, "%v can't have an otherwise clause", name)
+			}
+
+			if obj.(*cons.Cons).Cdr.Type() == types.Cons {
+				return nil, nil, errors.Errorf(errors.GeneralError, "%v expected the otherwise clause to be the last clause", name)
+			}
+
+			return compiled, bodyPart, nil
+		}
+
+		keys := []types.Object{}
+
+		if clause.Car.Type() == types.Cons || clause.Car.Type() == types.Null {
+			for key := clause.Car; key.Type() == types.Cons; key = key.(*cons.Cons).Cdr {
+				keys = append(keys, key.(*cons.Cons).Car)
+			}
+		} else {
+			keys = append(keys, clause.Car)
+		}
+
+		for _, key := range keys {
+			err := checkKey(key)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		compiled = append(compiled, &caseClause{
+			keys: keys,
+			body: bodyPart,
+		})
+	}
+
+	return compiled, nil, nil
+}
+
+// anyKey accepts every object as key
+func anyKey(key types.Object) error {
+	return nil
+}
+
+// compileCase compiles CASE and ECASE, for ECASE it is an error if no clause
+// matches the key
+func compileCase(name string, args *cons.Cons, exhaustive bool, c environment.Compiler) (environment.Closure, error) {
+	keyForm := c.Compile(args.Car)
+
+	clauses, otherwise, err := compileCaseClauses(name, args.Cdr, !exhaustive, anyKey, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		key, err := keyForm(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, clause := range clauses {
+			if clause.matches(key) {
+				return clause.body(env, context)
+			}
+		}
+
+		if otherwise != nil {
+			return otherwise(env, context)
+		}
+
+		if exhaustive {
+			return nil, errors.Errorf(errors.TypeError, "no %v clause matches %v", name, key)
+		}
+
+		return types.NIL, nil
+	}, nil
+}
+
+// CompileCase compiles a case special form, the body of the first clause
+// with a key EQL to the value of the key form is evaluated
+func CompileCase(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileCase("CASE", args, false, c)
+}
+
+// CompileEcase compiles an ecase special form, ECASE has no otherwise clause
+// and signals an error if no clause matches
+func CompileEcase(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileCase("ECASE", args, true, c)
+}
+
+// CompileTypecase compiles a typecase special form, the keys are the type
+// keywords returned by TYPE-OF
+func CompileTypecase(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	keyForm := c.Compile(args.Car)
+
+	clauses, otherwise, err := compileCaseClauses("TYPECASE", args.Cdr, true, func(key types.Object) error {
+		if sym, ok := key.(*symbols.Symbol); !ok || !sym.IsKeyword {
+			return errors.Errorf(errors.TypeError, "TYPECASE expected a type keyword, got %v", key)
+		}
+
+		return nil
+	}, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		obj, err := keyForm(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		typeSym := typeOf(obj, env)
+
+		for _, clause := range clauses {
+			if clause.matches(typeSym) {
+				return clause.body(env, context)
+			}
+		}
+
+		if otherwise != nil {
+			return otherwise(env, context)
+		}
+
+		return types.NIL, nil
+	}, nil
+}
+
+// CreateBuiltinCase creates a builtin function object
+func CreateBuiltinCase() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileCase, 1, function.Variadic)
+}
+
+// CreateBuiltinEcase creates a builtin function object
+func CreateBuiltinEcase() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileEcase, 1, function.Variadic)
+}
+
+// CreateBuiltinTypecase creates a builtin function object
+func CreateBuiltinTypecase() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileTypecase, 1, function.Variadic)
+}
@@ -0,0 +1,68 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+)
+
+const (
+	catchExit = "CATCH"
+)
+
+// CompileCatch compiles a catch special form, the tag is evaluated and
+// THROW-TAG exits the innermost active catch with an eql tag
+func CompileCatch(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	tag := c.Compile(args.Car)
+	body := CompileProgn(args.Cdr, c)
+
+	return func(env environment.Environment, context interface{}) (result types.Object, err error) {
+		obj, err := tag(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		point := &function.ExitPoint{Kind: catchExit, Tag: obj}
+		points := pushExitPoints(env, point)
+
+		defer func() {
+			setExitPoints(env, points)
+
+			if r := recover(); r != nil {
+				result, err = function.Landed(r, point), nil
+			}
+		}()
+
+		return body(env, context)
+	}, nil
+}
+
+// ThrowTag builtin function
+func ThrowTag(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	var obj types.Object = types.NIL
+	if args.Cdr.Type() == types.Cons {
+		obj = args.Cdr.(*cons.Cons).Car
+	}
+
+	point := findExitPoint(catchExit, args.Car, env)
+	if point == nil {
+		return nil, errors.Errorf(errors.GeneralError, "THROW-TAG no active catch for tag %v", args.Car)
+	}
+
+	function.ExitTo(point, obj)
+
+	return nil, nil
+}
+
+// CreateBuiltinCatch creates a builtin function object
+func CreateBuiltinCatch() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileCatch, 1, function.Variadic)
+}
+
+// CreateBuiltinThrowTag creates a builtin function object
+func CreateBuiltinThrowTag() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(ThrowTag, 1, 2, true)
+}
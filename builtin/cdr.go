@@ -1,11 +1,10 @@
 package builtin
 
 import (
-	"errors"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
@@ -21,10 +20,10 @@ func Cdr(args *cons.Cons, env environment.Environment, context interface{}) (typ
 		return obj.(*cons.Cons).Cdr, nil
 	}
 
-	return nil, errors.New("CDR expects a list as argument")
+	return nil, errors.New(errors.TypeError, "CDR expects a list as argument")
 }
 
 // CreateBuiltinCdr creates a builtin function object
 func CreateBuiltinCdr() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Cdr, 1, true)
+	return functions.NewBuiltinFunction(Cdr, 1, 1, true)
 }
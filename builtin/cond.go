@@ -0,0 +1,120 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+)
+
+// condClause is a compiled clause of COND, body is nil if the clause has no
+// body forms, the value of the test is returned in that case
+type condClause struct {
+	test environment.Closure
+	body environment.Closure
+}
+
+// CompileCond compiles a cond special form, the clauses have the form
+// (test body...) and the body of the first clause whose test is not NIL is
+// evaluated
+func CompileCond(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	clauses := []*condClause{}
+
+	var forms types.Object = types.NIL
+	if args != nil {
+		forms = args
+	}
+
+	for obj := forms; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		clause, ok := obj.(*cons.Cons).Car.(*cons.Cons)
+		if !ok {
+			return nil, errors.New(errors.GeneralError, "COND expected clauses of the form (test body...)")
+		}
+
+		compiled := &condClause{
+			test: c.Compile(clause.Car),
+		}
+
+		if clause.Cdr.Type() == types.Cons {
+			// Body is in tail position
+			compiled.body = c.CompileBody(clause.Cdr.(*cons.Cons))
+		}
+
+		clauses = append(clauses, compiled)
+	}
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		for _, clause := range clauses {
+			result, err := clause.test(env, context)
+			if err != nil {
+				return nil, err
+			}
+
+			if result == types.NIL {
+				continue
+			}
+
+			if clause.body == nil {
+				return result, nil
+			}
+
+			return clause.body(env, context)
+		}
+
+		return types.NIL, nil
+	}, nil
+}
+
+// compileWhen compiles the test and body of WHEN and UNLESS, the body is
+// evaluated if the test result is not NIL or NIL respectively
+func compileWhen(args *cons.Cons, negate bool, c environment.Compiler) (environment.Closure, error) {
+	condition := c.Compile(args.Car)
+
+	var body *cons.Cons
+	if args.Cdr.Type() == types.Cons {
+		body = args.Cdr.(*cons.Cons)
+	}
+
+	// Body is in tail position
+	bodyPart := c.CompileBody(body)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		result, err := condition(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		if (result == types.NIL) != negate {
+			return types.NIL, nil
+		}
+
+		return bodyPart(env, context)
+	}, nil
+}
+
+// CompileWhen compiles a when special form
+func CompileWhen(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileWhen(args, false, c)
+}
+
+// CompileUnless compiles an unless special form
+func CompileUnless(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileWhen(args, true, c)
+}
+
+// CreateBuiltinCond creates a builtin function object
+func CreateBuiltinCond() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileCond, 0, function.Variadic)
+}
+
+// CreateBuiltinWhen creates a builtin function object
+func CreateBuiltinWhen() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileWhen, 1, function.Variadic)
+}
+
+// CreateBuiltinUnless creates a builtin function object
+func CreateBuiltinUnless() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileUnless, 1, function.Variadic)
+}
@@ -0,0 +1,146 @@
+package conditions
+
+import (
+	goErrors "errors"
+	goStrings "strings"
+
+	"github.com/almerlucke/glisp/builtin"
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+const (
+	handlersContext = "Handlers"
+	restartsContext = "Restarts"
+)
+
+// handler is a single clause of HANDLER-BIND or HANDLER-CASE, handle
+// declines the condition by returning nil
+type handler struct {
+	conditionType *symbols.Symbol
+	handle        func(cond *errors.Error, env environment.Environment, context interface{}) error
+}
+
+// Handlers are established in clusters, while a handler runs only the
+// clusters outside of its own cluster are active
+func getHandlers(env environment.Environment) [][]*handler {
+	handlers, _ := env.Context()[handlersContext].([][]*handler)
+	return handlers
+}
+
+func setHandlers(env environment.Environment, handlers [][]*handler) {
+	env.Context()[handlersContext] = handlers
+}
+
+// pushHandlers establishes a cluster and returns the handlers to restore
+func pushHandlers(env environment.Environment, cluster []*handler) [][]*handler {
+	handlers := getHandlers(env)
+
+	// Force a copy so the handlers of sibling frames are never overwritten
+	setHandlers(env, append(handlers[:len(handlers):len(handlers)], cluster))
+
+	return handlers
+}
+
+// matches checks if a condition is of the type named by a clause, :CONDITION
+// matches all conditions, :ERROR and :WARNING match on severity and other
+// names match the kind of the condition
+func matches(conditionType *symbols.Symbol, cond *errors.Error) bool {
+	switch conditionType.Name {
+	case "CONDITION":
+		return true
+	case "ERROR":
+		if cond.Severity == errors.ErrorSeverity {
+			return true
+		}
+	case "WARNING":
+		if cond.Severity == errors.WarningSeverity {
+			return true
+		}
+	}
+
+	return goStrings.EqualFold(conditionType.Name, string(cond.Kind))
+}
+
+// signal invokes the active handlers for a condition, innermost first, until
+// a handler transfers control. An error returned by a handler is returned
+func signal(cond *errors.Error, env environment.Environment, context interface{}) error {
+	cond.Signaled = true
+
+	handlers := getHandlers(env)
+
+	defer setHandlers(env, handlers)
+
+	for i := len(handlers) - 1; i >= 0; i-- {
+		for _, h := range handlers[i] {
+			if !matches(h.conditionType, cond) {
+				continue
+			}
+
+			setHandlers(env, handlers[:i])
+
+			err := h.handle(cond, env, context)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// signalError signals an error returned from the extent of a handler or
+// restart frame, errors are only signaled once on their way out. Returns the
+// error to pass on
+func signalError(err error, env environment.Environment, context interface{}) error {
+	// Cancellation can't be handled
+	if goErrors.Is(err, errors.ErrCancelled) {
+		return err
+	}
+
+	err, cond := errors.Find(err)
+	if cond.Signaled {
+		return err
+	}
+
+	herr := signal(cond, env, context)
+	if herr != nil {
+		return herr
+	}
+
+	return err
+}
+
+// compileClauses checks and compiles the clauses of HANDLER-CASE and
+// RESTART-CASE, each clause is a name followed by a lambda list and a body
+func compileClauses(name string, clauses types.Object, c environment.Compiler) ([]*symbols.Symbol, []environment.Closure, error) {
+	names := []*symbols.Symbol{}
+	lambdas := []environment.Closure{}
+
+	for clauses.Type() == types.Cons {
+		clause, ok := clauses.(*cons.Cons).Car.(*cons.Cons)
+		if !ok || clause.Cdr.Type() != types.Cons {
+			return nil, nil, errors.Errorf(errors.GeneralError, "%v expected clauses of the form (name (args) body...)", name)
+		}
+
+		clauseName, ok := clause.Car.(*symbols.Symbol)
+		if !ok {
+			return nil, nil, errors.Errorf(errors.TypeError, "%v expected a symbol as clause name", name)
+		}
+
+		lambda, err := builtin.CompileLambda(clause.Cdr.(*cons.Cons), c)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		names = append(names, clauseName)
+		lambdas = append(lambdas, lambda)
+
+		clauses = clauses.(*cons.Cons).Cdr
+	}
+
+	return names, lambdas, nil
+}
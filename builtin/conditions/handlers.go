@@ -0,0 +1,178 @@
+package conditions
+
+import (
+	"github.com/almerlucke/glisp/builtin"
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// handlerCaseFrame identifies a single evaluation of HANDLER-CASE
+type handlerCaseFrame struct {
+	cluster []*handler
+}
+
+// handlerCaseExit unwinds the stack to the HANDLER-CASE frame whose clause
+// handles the condition
+type handlerCaseExit struct {
+	frame *handlerCaseFrame
+	index int
+	cond  *errors.Error
+}
+
+// CompileHandlerBind compiles a handler-bind special form, the handlers are
+// called without unwinding the stack, a handler declines the condition by
+// returning normally
+func CompileHandlerBind(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	bindingsType := args.Car.Type()
+	if bindingsType != types.Cons && bindingsType != types.Null {
+		return nil, errors.New(errors.TypeError, "HANDLER-BIND expected a binding list as first argument")
+	}
+
+	conditionTypes := []*symbols.Symbol{}
+	handlerForms := []environment.Closure{}
+
+	for bindings := args.Car; bindings.Type() == types.Cons; bindings = bindings.(*cons.Cons).Cdr {
+		binding, ok := bindings.(*cons.Cons).Car.(*cons.Cons)
+		if !ok || binding.Cdr.Type() != types.Cons {
+			return nil, errors.New(errors.GeneralError, "HANDLER-BIND expected bindings of the form (type handler)")
+		}
+
+		conditionType, ok := binding.Car.(*symbols.Symbol)
+		if !ok {
+			return nil, errors.New(errors.TypeError, "HANDLER-BIND expected a symbol as condition type")
+		}
+
+		conditionTypes = append(conditionTypes, conditionType)
+		handlerForms = append(handlerForms, c.Compile(binding.Cdr.(*cons.Cons).Car))
+	}
+
+	body := builtin.CompileProgn(args.Cdr, c)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		cluster := make([]*handler, len(handlerForms))
+
+		for i, handlerForm := range handlerForms {
+			obj, err := handlerForm(env, context)
+			if err != nil {
+				return nil, err
+			}
+
+			fun, ok := obj.(function.Function)
+			if !ok {
+				return nil, errors.Errorf(errors.TypeError, "HANDLER-BIND expected a function as handler, got %v", obj)
+			}
+
+			cluster[i] = &handler{
+				conditionType: conditionTypes[i],
+				handle: func(cond *errors.Error, env environment.Environment, context interface{}) error {
+					_, err := functions.Apply(fun, cons.ListFromSlice([]types.Object{cond}), env, context)
+					return err
+				},
+			}
+		}
+
+		handlers := pushHandlers(env, cluster)
+
+		defer setHandlers(env, handlers)
+
+		result, err := body(env, context)
+		if err != nil {
+			return nil, signalError(err, env, context)
+		}
+
+		return result, nil
+	}, nil
+}
+
+// CompileHandlerCase compiles a handler-case special form, the stack is
+// unwound to the handler-case before the clause handling the condition is
+// evaluated with the condition bound to its argument
+func CompileHandlerCase(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	expression := c.Compile(args.Car)
+
+	conditionTypes, clauses, err := compileClauses("HANDLER-CASE", args.Cdr, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(env environment.Environment, context interface{}) (result types.Object, err error) {
+		frame := &handlerCaseFrame{
+			cluster: make([]*handler, len(clauses)),
+		}
+
+		for i := range clauses {
+			index := i
+
+			frame.cluster[i] = &handler{
+				conditionType: conditionTypes[i],
+				handle: func(cond *errors.Error, env environment.Environment, context interface{}) error {
+					panic(&handlerCaseExit{
+						frame: frame,
+						index: index,
+						cond:  cond,
+					})
+				},
+			}
+		}
+
+		handlers := pushHandlers(env, frame.cluster)
+
+		defer func() {
+			setHandlers(env, handlers)
+
+			if r := recover(); r != nil {
+				exit, ok := r.(*handlerCaseExit)
+				if !ok || exit.frame != frame {
+					// Continue to panic
+					panic(r)
+				}
+
+				result, err = evalHandlerClause(clauses[exit.index], exit.cond, env, context)
+			}
+		}()
+
+		result, err = expression(env, context)
+		if err != nil {
+			return nil, signalError(err, env, context)
+		}
+
+		return result, nil
+	}, nil
+}
+
+// evalClause creates the lambda of a clause and calls it with args
+func evalClause(clause environment.Closure, args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	fun, err := clause(env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	return functions.Apply(fun.(function.Function), args, env, context)
+}
+
+// evalHandlerClause creates the lambda of a HANDLER-CASE clause and calls it
+// with the condition, a clause with an empty lambda list is called without
+// arguments
+func evalHandlerClause(clause environment.Closure, cond *errors.Error, env environment.Environment, context interface{}) (types.Object, error) {
+	fun, err := clause(env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	return builtin.ApplyHandler(fun.(function.Function), cond, env, context)
+}
+
+// CreateBuiltinHandlerBind creates a builtin function object
+func CreateBuiltinHandlerBind() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileHandlerBind, 1, function.Variadic)
+}
+
+// CreateBuiltinHandlerCase creates a builtin function object
+func CreateBuiltinHandlerCase() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileHandlerCase, 1, function.Variadic)
+}
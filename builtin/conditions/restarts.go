@@ -0,0 +1,138 @@
+package conditions
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// restart is a single clause of RESTART-CASE
+type restart struct {
+	name  string
+	index int
+	frame *restartFrame
+}
+
+// restartFrame identifies a single evaluation of RESTART-CASE
+type restartFrame struct {
+	restarts []*restart
+}
+
+// restartExit unwinds the stack to the RESTART-CASE frame of the invoked
+// restart
+type restartExit struct {
+	restart *restart
+	args    *cons.Cons
+}
+
+func getRestarts(env environment.Environment) []*restart {
+	restarts, _ := env.Context()[restartsContext].([]*restart)
+	return restarts
+}
+
+func setRestarts(env environment.Environment, restarts []*restart) {
+	env.Context()[restartsContext] = restarts
+}
+
+// findRestart returns the innermost active restart with name, nil if there
+// is none
+func findRestart(name string, env environment.Environment) *restart {
+	restarts := getRestarts(env)
+
+	for i := len(restarts) - 1; i >= 0; i-- {
+		if restarts[i].name == name {
+			return restarts[i]
+		}
+	}
+
+	return nil
+}
+
+// CompileRestartCase compiles a restart-case special form, the restarts are
+// active while the expression is evaluated, invoking a restart unwinds the
+// stack to the restart-case and evaluates the restart clause
+func CompileRestartCase(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	expression := c.Compile(args.Car)
+
+	names, clauses, err := compileClauses("RESTART-CASE", args.Cdr, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(env environment.Environment, context interface{}) (result types.Object, err error) {
+		frame := &restartFrame{
+			restarts: make([]*restart, len(clauses)),
+		}
+
+		for i, name := range names {
+			frame.restarts[i] = &restart{
+				name:  name.Name,
+				index: i,
+				frame: frame,
+			}
+		}
+
+		restarts := getRestarts(env)
+
+		// Force a copy so the restarts of sibling frames are never overwritten
+		setRestarts(env, append(restarts[:len(restarts):len(restarts)], frame.restarts...))
+
+		defer func() {
+			setRestarts(env, restarts)
+
+			if r := recover(); r != nil {
+				exit, ok := r.(*restartExit)
+				if !ok || exit.restart.frame != frame {
+					// Continue to panic
+					panic(r)
+				}
+
+				result, err = evalClause(clauses[exit.restart.index], exit.args, env, context)
+			}
+		}()
+
+		result, err = expression(env, context)
+		if err != nil {
+			return nil, signalError(err, env, context)
+		}
+
+		return result, nil
+	}, nil
+}
+
+// InvokeRestart builtin function
+func InvokeRestart(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	name, ok := args.Car.(*symbols.Symbol)
+	if !ok {
+		return nil, errors.New(errors.TypeError, "INVOKE-RESTART expected a symbol as first argument")
+	}
+
+	r := findRestart(name.Name, env)
+	if r == nil {
+		return nil, errors.Errorf(errors.GeneralError, "no active restart named %v", name)
+	}
+
+	var restartArgs *cons.Cons
+	if args.Cdr.Type() == types.Cons {
+		restartArgs = args.Cdr.(*cons.Cons)
+	}
+
+	panic(&restartExit{
+		restart: r,
+		args:    restartArgs,
+	})
+}
+
+// CreateBuiltinRestartCase creates a builtin function object
+func CreateBuiltinRestartCase() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileRestartCase, 1, function.Variadic)
+}
+
+// CreateBuiltinInvokeRestart creates a builtin function object
+func CreateBuiltinInvokeRestart() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(InvokeRestart, 1, function.Variadic, true)
+}
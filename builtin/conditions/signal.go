@@ -0,0 +1,192 @@
+package conditions
+
+import (
+	"fmt"
+	"os"
+	goStrings "strings"
+
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/strings"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// makeCondition creates a condition from the arguments of ERROR, WARN and
+// SIGNAL, the arguments are either a condition, a message string or a
+// keyword kind followed by a message string and an optional object. A
+// condition is copied so signaling it again doesn't change the position and
+// stack of the original
+func makeCondition(name string, args *cons.Cons, kind errors.Kind, severity errors.Severity) (*errors.Error, error) {
+	if cond, ok := args.Car.(*errors.Error); ok {
+		return cond.Copy(), nil
+	}
+
+	cond := &errors.Error{
+		Kind:     kind,
+		Severity: severity,
+	}
+
+	if sym, ok := args.Car.(*symbols.Symbol); ok && sym.IsKeyword {
+		cond.Kind = errors.Kind(goStrings.ToLower(sym.Name))
+
+		if args.Cdr.Type() != types.Cons {
+			cond.Message = string(cond.Kind)
+			return cond, nil
+		}
+
+		args = args.Cdr.(*cons.Cons)
+	}
+
+	message, ok := args.Car.(strings.String)
+	if !ok {
+		return nil, errors.Errorf(errors.TypeError, "%v expected a condition, a message string or a keyword", name)
+	}
+
+	cond.Message = string(message)
+
+	if args.Cdr.Type() == types.Cons {
+		cond.Object = args.Cdr.(*cons.Cons).Car
+	}
+
+	return cond, nil
+}
+
+// Signal builtin function, returns NIL if no handler transfers control
+func Signal(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	cond, err := makeCondition("SIGNAL", args, errors.SimpleCondition, errors.ConditionSeverity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = signal(cond, env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NIL, nil
+}
+
+// Error builtin function, the condition is returned as error if no handler
+// transfers control
+func Error(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	cond, err := makeCondition("ERROR", args, errors.GeneralError, errors.ErrorSeverity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = signal(cond, env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, cond
+}
+
+// Warn builtin function, the warning is printed if no handler transfers
+// control, a handler can invoke the MUFFLE-WARNING restart to suppress it
+func Warn(args *cons.Cons, env environment.Environment, context interface{}) (result types.Object, err error) {
+	cond, err := makeCondition("WARN", args, errors.WarningCondition, errors.WarningSeverity)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := &restartFrame{}
+	frame.restarts = []*restart{{
+		name:  "MUFFLE-WARNING",
+		frame: frame,
+	}}
+
+	restarts := getRestarts(env)
+	setRestarts(env, append(restarts[:len(restarts):len(restarts)], frame.restarts...))
+
+	defer func() {
+		setRestarts(env, restarts)
+
+		if r := recover(); r != nil {
+			exit, ok := r.(*restartExit)
+			if !ok || exit.restart.frame != frame {
+				// Continue to panic
+				panic(r)
+			}
+
+			result, err = types.NIL, nil
+		}
+	}()
+
+	err = signal(cond, env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(os.Stderr, "WARNING: %v\n", cond.Message)
+
+	return types.NIL, nil
+}
+
+// ConditionType builtin function, returns the kind of a condition as keyword
+func ConditionType(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	cond, ok := args.Car.(*errors.Error)
+	if !ok {
+		return nil, errors.New(errors.TypeError, "CONDITION-TYPE expected a condition as first argument")
+	}
+
+	return env.InternKeyword(goStrings.ToUpper(string(cond.Kind))), nil
+}
+
+// ConditionMessage builtin function
+func ConditionMessage(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	cond, ok := args.Car.(*errors.Error)
+	if !ok {
+		return nil, errors.New(errors.TypeError, "CONDITION-MESSAGE expected a condition as first argument")
+	}
+
+	return strings.String(cond.Message), nil
+}
+
+// ConditionObject builtin function, returns NIL if the condition has no
+// object
+func ConditionObject(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	cond, ok := args.Car.(*errors.Error)
+	if !ok {
+		return nil, errors.New(errors.TypeError, "CONDITION-OBJECT expected a condition as first argument")
+	}
+
+	if cond.Object == nil {
+		return types.NIL, nil
+	}
+
+	return cond.Object, nil
+}
+
+// CreateBuiltinSignal creates a builtin function object
+func CreateBuiltinSignal() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(Signal, 1, 3, true)
+}
+
+// CreateBuiltinError creates a builtin function object
+func CreateBuiltinError() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(Error, 1, 3, true)
+}
+
+// CreateBuiltinWarn creates a builtin function object
+func CreateBuiltinWarn() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(Warn, 1, 3, true)
+}
+
+// CreateBuiltinConditionType creates a builtin function object
+func CreateBuiltinConditionType() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(ConditionType, 1, 1, true)
+}
+
+// CreateBuiltinConditionMessage creates a builtin function object
+func CreateBuiltinConditionMessage() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(ConditionMessage, 1, 1, true)
+}
+
+// CreateBuiltinConditionObject creates a builtin function object
+func CreateBuiltinConditionObject() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(ConditionObject, 1, 1, true)
+}
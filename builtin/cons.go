@@ -20,5 +20,5 @@ func Cons(args *cons.Cons, env environment.Environment, context interface{}) (ty
 
 // CreateBuiltinCons creates a builtin function object
 func CreateBuiltinCons() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Cons, 2, true)
+	return functions.NewBuiltinFunction(Cons, 2, 2, true)
 }
@@ -0,0 +1,164 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/strings"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// functionCompiler compiles the lambda list and body of a function
+type functionCompiler func(string, *cons.Cons, *functions.Description, environment.Compiler) (environment.Closure, error)
+
+// docstring splits a function body in a docstring and the remaining body, a
+// string is only a docstring if it is followed by other forms
+func docstring(body types.Object) (string, types.Object) {
+	if body.Type() != types.Cons {
+		return "", body
+	}
+
+	doc, ok := body.(*cons.Cons).Car.(strings.String)
+	if !ok || body.(*cons.Cons).Cdr.Type() != types.Cons {
+		return "", body
+	}
+
+	return string(doc), body.(*cons.Cons).Cdr
+}
+
+// compileDefinition compiles a named function definition of the form
+// (name lambda-list [docstring] body...), the function is bound globally to
+// name and the name is returned
+func compileDefinition(name string, args *cons.Cons, compile functionCompiler, c environment.Compiler) (environment.Closure, error) {
+	sym, ok := args.Car.(*symbols.Symbol)
+	if !ok || sym.IsKeyword {
+		return nil, errors.Errorf(errors.TypeError, "%v expected a symbol as first argument", name)
+	}
+
+	if sym.Reserved {
+		return nil, errors.Errorf(errors.GeneralError, "%v can't define reserved symbol %v", name, sym)
+	}
+
+	if sym.Special {
+		return nil, errors.Errorf(errors.GeneralError, "%v can't define special symbol %v", name, sym)
+	}
+
+	definition := args.Cdr.(*cons.Cons)
+	doc, body := docstring(definition.Cdr)
+
+	closure, err := compile(name, &cons.Cons{Car: definition.Car, Cdr: body}, &functions.Description{
+		Name: sym,
+		Doc:  doc,
+	}, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		fun, err := closure(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		env.AddGlobalBinding(sym, fun)
+
+		return sym, nil
+	}, nil
+}
+
+// CompileDefun compiles a defun special form
+func CompileDefun(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileDefinition("DEFUN", args, compileLambda, c)
+}
+
+// CompileDefmacro compiles a defmacro special form
+func CompileDefmacro(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileDefinition("DEFMACRO", args, compileMacro, c)
+}
+
+// describedFunction returns the description of a lambda or macro function,
+// nil for an anonymous function or a builtin function
+func describedFunction(name string, obj types.Object) (functions.Described, *functions.Description, error) {
+	if _, ok := obj.(function.Function); !ok {
+		return nil, nil, errors.Errorf(errors.TypeError, "%v expected a function as first argument", name)
+	}
+
+	described, ok := obj.(functions.Described)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	return described, described.Description(), nil
+}
+
+// Documentation builtin function, returns the docstring of a function or NIL
+func Documentation(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	_, description, err := describedFunction("DOCUMENTATION", args.Car)
+	if err != nil {
+		return nil, err
+	}
+
+	if description == nil || description.Doc == "" {
+		return types.NIL, nil
+	}
+
+	return strings.String(description.Doc), nil
+}
+
+// FunctionName builtin function, returns the name of a function or NIL for an
+// anonymous function
+func FunctionName(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	_, description, err := describedFunction("FUNCTION-NAME", args.Car)
+	if err != nil {
+		return nil, err
+	}
+
+	if description == nil {
+		return types.NIL, nil
+	}
+
+	return description.Name, nil
+}
+
+// FunctionLambdaList builtin function, returns the lambda list of a lambda or
+// macro function, NIL for builtin functions
+func FunctionLambdaList(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	described, _, err := describedFunction("FUNCTION-LAMBDA-LIST", args.Car)
+	if err != nil {
+		return nil, err
+	}
+
+	if described == nil {
+		return types.NIL, nil
+	}
+
+	return described.LambdaList().Form, nil
+}
+
+// CreateBuiltinDefun creates a builtin function object
+func CreateBuiltinDefun() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDefun, 2, function.Variadic)
+}
+
+// CreateBuiltinDefmacro creates a builtin function object
+func CreateBuiltinDefmacro() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDefmacro, 2, function.Variadic)
+}
+
+// CreateBuiltinDocumentation creates a builtin function object
+func CreateBuiltinDocumentation() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(Documentation, 1, 1, true)
+}
+
+// CreateBuiltinFunctionName creates a builtin function object
+func CreateBuiltinFunctionName() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(FunctionName, 1, 1, true)
+}
+
+// CreateBuiltinFunctionLambdaList creates a builtin function object
+func CreateBuiltinFunctionLambdaList() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(FunctionLambdaList, 1, 1, true)
+}
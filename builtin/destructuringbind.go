@@ -0,0 +1,62 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/functions"
+)
+
+// CompileDestructuringBind compiles a destructuring-bind special form, the
+// value of the expression is destructured by the lambda list and the body is
+// evaluated in a new scope holding the parameters
+func CompileDestructuringBind(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	expression := c.Compile(args.Cdr.(*cons.Cons).Car)
+
+	bodyCompiler := c.NewScope()
+
+	lambdaList, err := compileDestructuringLambdaList("DESTRUCTURING-BIND", args.Car, bodyCompiler, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var body *cons.Cons
+	if args.Cdr.(*cons.Cons).Cdr.Type() == types.Cons {
+		body = args.Cdr.(*cons.Cons).Cdr.(*cons.Cons)
+	}
+
+	layout := bodyCompiler.Layout()
+	compiledBody := bodyCompiler.CompileBody(body)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		value, err := expression(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		s := scope.New(layout, env.CurrentScope())
+
+		// Push a new scope for the parameters, default forms are evaluated
+		// in this scope
+		env.PushScope(s)
+
+		// Make sure we pop the scope after completion
+		defer env.PopScope()
+
+		err = lambdaList.Bind("DESTRUCTURING-BIND", s, value, value, env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		// Last form is in tail position, arguments of a tail call are
+		// evaluated before the scope is popped
+		return compiledBody(env, context)
+	}, nil
+}
+
+// CreateBuiltinDestructuringBind creates a builtin function object
+func CreateBuiltinDestructuringBind() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDestructuringBind, 2, function.Variadic)
+}
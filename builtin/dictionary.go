@@ -1,12 +1,12 @@
 package builtin
 
 import (
-	"errors"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
 	"github.com/almerlucke/glisp/types/dictionaries"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
@@ -14,14 +14,19 @@ import (
 func Dictionary(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	dictionary := make(dictionaries.Dictionary)
 
+	// Called without key value pairs
+	if args == nil {
+		return dictionary, nil
+	}
+
 	err := args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		if obj.Type() != types.Cons {
-			return false, errors.New("illegal key value pair for DICTIONARY")
+			return false, errors.New(errors.GeneralError, "illegal key value pair for DICTIONARY")
 		}
 
 		pair := obj.(*cons.Cons)
 		if pair.Length() != 2 {
-			return false, errors.New("illegal key value pair for DICTIONARY")
+			return false, errors.New(errors.GeneralError, "illegal key value pair for DICTIONARY")
 		}
 
 		key := pair.Car
@@ -39,5 +44,5 @@ func Dictionary(args *cons.Cons, env environment.Environment, context interface{
 
 // CreateBuiltinDictionary creates a builtin function object
 func CreateBuiltinDictionary() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Dictionary, 0, true)
+	return functions.NewBuiltinFunction(Dictionary, 0, function.Variadic, true)
 }
@@ -2,25 +2,17 @@ package builtin
 
 import (
 	"github.com/almerlucke/glisp/interfaces/environment"
-	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types/cons"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
-// Do builtin function
-func Do(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	var result types.Object = types.NIL
-	var err error
-
-	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
-		result, err = env.Eval(obj, context)
-		return false, err
-	})
-
-	return result, err
+// CompileDo compiles a do special form
+func CompileDo(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return c.CompileBody(args), nil
 }
 
 // CreateBuiltinDo creates a builtin function object
-func CreateBuiltinDo() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Do, 0, false)
+func CreateBuiltinDo() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDo, 0, function.Variadic)
 }
@@ -1,12 +1,11 @@
 package builtin
 
 import (
-	"errors"
-
 	"github.com/almerlucke/glisp/interfaces/collection"
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
@@ -15,7 +14,7 @@ func Elt(args *cons.Cons, env environment.Environment, context interface{}) (typ
 	col, ok := args.Car.(collection.Collection)
 
 	if !ok {
-		return nil, errors.New("ELT expected a collection as first argument")
+		return nil, errors.New(errors.TypeError, "ELT expected a collection as first argument")
 	}
 
 	index := args.Cdr.(*cons.Cons).Car
@@ -33,7 +32,7 @@ func EltAssign(args *cons.Cons, val types.Object, env environment.Environment, c
 	col, ok := args.Car.(collection.Collection)
 
 	if !ok {
-		return nil, errors.New("ELT expected a collection as first argument")
+		return nil, errors.New(errors.TypeError, "ELT expected a collection as first argument")
 	}
 
 	index := args.Cdr.(*cons.Cons).Car
@@ -48,5 +47,5 @@ func EltAssign(args *cons.Cons, val types.Object, env environment.Environment, c
 
 // CreateBuiltinElt creates a assignable function object
 func CreateBuiltinElt() *functions.AssignableFunction {
-	return functions.NewAssignableFunction(Elt, EltAssign, 2, true)
+	return functions.NewAssignableFunction(Elt, EltAssign, 2, 2, true)
 }
@@ -31,10 +31,10 @@ func Equal(args *cons.Cons, env environment.Environment, context interface{}) (t
 
 // CreateBuiltinEql creates a builtin function object
 func CreateBuiltinEql() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Eql, 2, true)
+	return functions.NewBuiltinFunction(Eql, 2, 2, true)
 }
 
 // CreateBuiltinEqual creates a builtin function object
 func CreateBuiltinEqual() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Equal, 2, true)
+	return functions.NewBuiltinFunction(Equal, 2, 2, true)
 }
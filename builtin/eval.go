@@ -9,10 +9,12 @@ import (
 
 // Eval builtin function
 func Eval(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	return env.Eval(args.Car, context)
+	return functions.Nested(env, func() (types.Object, error) {
+		return env.Eval(args.Car, context)
+	})
 }
 
 // CreateBuiltinEval creates a builtin function object
 func CreateBuiltinEval() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Eval, 1, true)
+	return functions.NewBuiltinFunction(Eval, 1, 1, true)
 }
@@ -19,5 +19,5 @@ func Exit(args *cons.Cons, env environment.Environment, context interface{}) (ty
 
 // CreateBuiltinExit creates a builtin function object
 func CreateBuiltinExit() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Exit, 0, false)
+	return functions.NewBuiltinFunction(Exit, 0, 0, false)
 }
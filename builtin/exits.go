@@ -0,0 +1,87 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+)
+
+const (
+	exitPointsContext = "ExitPoints"
+)
+
+// Exit points of BLOCK, CATCH and TAGBODY are kept in a list of active exit
+// points, innermost first, so forms can check an exit point is active before
+// exiting to it. CATCH tags are looked up dynamically, BLOCK names and
+// TAGBODY tags are labels resolved at compile time. The exit point of a
+// label is tagged with an object bound to the label variable when the exit
+// point is established
+func getExitPoints(env environment.Environment) []*function.ExitPoint {
+	points, _ := env.Context()[exitPointsContext].([]*function.ExitPoint)
+	return points
+}
+
+func setExitPoints(env environment.Environment, points []*function.ExitPoint) {
+	env.Context()[exitPointsContext] = points
+}
+
+// pushExitPoints establishes exit points and returns the exit points to
+// restore
+func pushExitPoints(env environment.Environment, added ...*function.ExitPoint) []*function.ExitPoint {
+	points := getExitPoints(env)
+
+	// Force a copy so the exit points of sibling forms are never overwritten
+	setExitPoints(env, append(points[:len(points):len(points)], added...))
+
+	return points
+}
+
+// findExitPoint returns the innermost active exit point of kind with a tag
+// eql to tag, nil if there is none
+func findExitPoint(kind string, tag types.Object, env environment.Environment) *function.ExitPoint {
+	points := getExitPoints(env)
+
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i].Kind == kind && points[i].Tag.Eql(tag) {
+			return points[i]
+		}
+	}
+
+	return nil
+}
+
+// labelExitPoint returns the active exit point bound to the variable of a
+// label, nil if the form that established it has returned
+func labelExitPoint(kind string, ref environment.Reference, env environment.Environment) (*function.ExitPoint, error) {
+	tag, err := ref.Get(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return findExitPoint(kind, tag, env), nil
+}
+
+// CompileProgn compiles a sequence of forms outside of tail position, use it
+// for bodies that must be evaluated while the form that compiles them is
+// still established. The closure returns NIL for an empty body
+func CompileProgn(body types.Object, c environment.Compiler) environment.Closure {
+	var compiled []environment.Closure
+	if body.Type() == types.Cons {
+		compiled = c.CompileArgs(body.(*cons.Cons))
+	}
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		var result types.Object = types.NIL
+		var err error
+
+		for _, form := range compiled {
+			result, err = form(env, context)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return result, nil
+	}
+}
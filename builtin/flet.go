@@ -0,0 +1,153 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// localFunction is a compiled function definition of FLET or LABELS
+type localFunction struct {
+	ref    environment.Reference
+	lambda environment.Closure
+}
+
+// compileLocalFunctions compiles the local functions and body of FLET and
+// LABELS. A definition has the form (name lambda-list [docstring] body...),
+// the function bodies of LABELS are compiled in the new scope so the
+// functions can call themselves and each other
+func compileLocalFunctions(name string, args *cons.Cons, recursive bool, c environment.Compiler) (environment.Closure, error) {
+	definitionsType := args.Car.Type()
+	if definitionsType != types.Cons && definitionsType != types.Null {
+		return nil, errors.Errorf(errors.TypeError, "%v expected a function definition list as first argument", name)
+	}
+
+	bodyCompiler := c.NewScope()
+	definitions := []*cons.Cons{}
+	funs := []*localFunction{}
+
+	// Define all names first, for LABELS the names are visible in the
+	// function bodies
+	for obj := args.Car; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		definition, ok := obj.(*cons.Cons).Car.(*cons.Cons)
+		if !ok || definition.Cdr.Type() != types.Cons {
+			return nil, errors.Errorf(errors.GeneralError, "%v expected definitions of the form (name lambda-list body...)", name)
+		}
+
+		sym, ok := definition.Car.(*symbols.Symbol)
+		if !ok || sym.IsKeyword {
+			return nil, errors.Errorf(errors.TypeError, "%v expected a symbol as function name, got %v", name, definition.Car)
+		}
+
+		if sym.Reserved || sym.Special {
+			return nil, errors.Errorf(errors.GeneralError, "%v can't bind symbol %v", name, sym)
+		}
+
+		for _, defined := range definitions {
+			if defined.Car == sym {
+				return nil, errors.Errorf(errors.GeneralError, "%v defines function %v more than once", name, sym)
+			}
+		}
+
+		definitions = append(definitions, definition)
+		funs = append(funs, &localFunction{ref: bodyCompiler.Define(sym)})
+	}
+
+	lambdaCompiler := c
+	if recursive {
+		lambdaCompiler = bodyCompiler
+	}
+
+	for i, definition := range definitions {
+		lambdaArgs := definition.Cdr.(*cons.Cons)
+		doc, body := docstring(lambdaArgs.Cdr)
+
+		lambda, err := compileLambda(name, &cons.Cons{Car: lambdaArgs.Car, Cdr: body}, &functions.Description{
+			Name: definition.Car.(*symbols.Symbol),
+			Doc:  doc,
+		}, lambdaCompiler)
+		if err != nil {
+			return nil, err
+		}
+
+		funs[i].lambda = lambda
+	}
+
+	var body *cons.Cons
+	if args.Cdr.Type() == types.Cons {
+		body = args.Cdr.(*cons.Cons)
+	}
+
+	compiledBody := bodyCompiler.CompileBody(body)
+	layout := bodyCompiler.Layout()
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		var lambdas []types.Object
+
+		if !recursive {
+			// FLET functions capture the enclosing scope, so they are
+			// created before the new scope is pushed
+			lambdas = make([]types.Object, len(funs))
+
+			for i, fun := range funs {
+				lambda, err := fun.lambda(env, context)
+				if err != nil {
+					return nil, err
+				}
+
+				lambdas[i] = lambda
+			}
+		}
+
+		// Push a new scope
+		env.PushScope(scope.New(layout, env.CurrentScope()))
+
+		// Make sure we pop the scope after completion
+		defer env.PopScope()
+
+		for i, fun := range funs {
+			if recursive {
+				// LABELS functions capture the new scope
+				lambda, err := fun.lambda(env, context)
+				if err != nil {
+					return nil, err
+				}
+
+				fun.ref.Bind(env, lambda)
+			} else {
+				fun.ref.Bind(env, lambdas[i])
+			}
+		}
+
+		// Last form is in tail position, arguments of a tail call are
+		// evaluated before the scope is popped
+		return compiledBody(env, context)
+	}, nil
+}
+
+// CompileFlet compiles a flet special form, the local functions are bound
+// in a new scope for the body and can't refer to each other
+func CompileFlet(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileLocalFunctions("FLET", args, false, c)
+}
+
+// CompileLabels compiles a labels special form, the local functions can call
+// themselves and each other
+func CompileLabels(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileLocalFunctions("LABELS", args, true, c)
+}
+
+// CreateBuiltinFlet creates a builtin function object
+func CreateBuiltinFlet() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileFlet, 1, function.Variadic)
+}
+
+// CreateBuiltinLabels creates a builtin function object
+func CreateBuiltinLabels() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileLabels, 1, function.Variadic)
+}
@@ -14,5 +14,5 @@ func Gensym(args *cons.Cons, env environment.Environment, context interface{}) (
 
 // CreateBuiltinGensym creates a builtin function object
 func CreateBuiltinGensym() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Gensym, 0, false)
+	return functions.NewBuiltinFunction(Gensym, 0, 0, false)
 }
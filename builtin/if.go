@@ -7,28 +7,35 @@ import (
 	"github.com/almerlucke/glisp/types/functions"
 )
 
-// If else
-func If(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	result, err := env.Eval(args.Car, context)
-	if err != nil {
-		return nil, err
-	}
+// CompileIf compiles an if else special form
+func CompileIf(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	condition := c.Compile(args.Car)
 
 	ifPart := args.Cdr.(*cons.Cons)
 
-	if result != types.NIL {
-		result, err = env.Eval(ifPart.Car, context)
-	} else {
-		elsePart := ifPart.Cdr
-		if elsePart.Type() == types.Cons {
-			result, err = env.Eval(elsePart.(*cons.Cons).Car, context)
-		}
+	// Branches are in tail position
+	thenPart := c.CompileTail(ifPart.Car)
+	elsePart := c.CompileTail(types.NIL)
+
+	if ifPart.Cdr.Type() == types.Cons {
+		elsePart = c.CompileTail(ifPart.Cdr.(*cons.Cons).Car)
 	}
 
-	return result, err
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		result, err := condition(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		if result != types.NIL {
+			return thenPart(env, context)
+		}
+
+		return elsePart(env, context)
+	}, nil
 }
 
 // CreateBuiltinIf creates a builtin function object
-func CreateBuiltinIf() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(If, 2, false)
+func CreateBuiltinIf() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileIf, 2, 3)
 }
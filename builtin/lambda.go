@@ -1,54 +1,29 @@
 package builtin
 
 import (
-	"errors"
-	"fmt"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
 	"github.com/almerlucke/glisp/types/functions"
-	"github.com/almerlucke/glisp/types/symbols"
-)
-
-// Lambda builtin function
-func Lambda(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	argType := args.Car.Type()
-
-	// Arg list must be cons or nil
-	if argType != types.Cons && argType != types.Null {
-		return nil, errors.New("LAMBDA expected an arg list as first argument")
-	}
-
-	symList := []*symbols.Symbol{}
-
-	if argType == types.Cons {
-		argList := args.Car.(*cons.Cons)
-		pure, length := argList.Info()
-		if !pure {
-			return nil, errors.New("LAMBDA arg list must be a pure list")
-		}
 
-		symList = make([]*symbols.Symbol, length)
-
-		// Build sym arg slice
-		err := argList.Iter(func(obj types.Object, index interface{}) (bool, error) {
-			if obj.Type() != types.Symbol {
-				return false, errors.New("LAMBDA arg list must contain only symbols")
-			}
-
-			if obj.(*symbols.Symbol).Reserved {
-				return false, fmt.Errorf("LAMBDA arg list contains reserved symbol %v", obj.(*symbols.Symbol))
-			}
+	globals "github.com/almerlucke/glisp/globals/symbols"
+)
 
-			symList[index.(uint64)] = obj.(*symbols.Symbol)
+// CompileLambda compiles a lambda special form
+func CompileLambda(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileLambda("LAMBDA", args, nil, c)
+}
 
-			return false, nil
-		})
+// compileLambda compiles the lambda list and body of a lambda function, the
+// description is nil for an anonymous function
+func compileLambda(name string, args *cons.Cons, description *functions.Description, c environment.Compiler) (environment.Closure, error) {
+	// Arguments are bound to the first slots of the layout
+	bodyCompiler := c.NewScope()
 
-		if err != nil {
-			return nil, err
-		}
+	lambdaList, err := compileLambdaList(name, args.Car, bodyCompiler)
+	if err != nil {
+		return nil, err
 	}
 
 	var body *cons.Cons
@@ -56,10 +31,19 @@ func Lambda(args *cons.Cons, env environment.Environment, context interface{}) (
 		body = args.Cdr.(*cons.Cons)
 	}
 
-	return functions.NewLambdaFunction(symList, env.CaptureScope(), body), nil
+	bodyCompiler.Define(globals.SelfSymbol)
+
+	layout := bodyCompiler.Layout()
+	compiledBody := bodyCompiler.CompileBody(body)
+
+	pos := c.Position()
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		return functions.NewLambdaFunction(lambdaList, description, layout, env.CaptureScope(), compiledBody, pos), nil
+	}, nil
 }
 
 // CreateBuiltinLambda creates a builtin function object
-func CreateBuiltinLambda() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Lambda, 1, false)
+func CreateBuiltinLambda() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileLambda, 1, function.Variadic)
 }
@@ -0,0 +1,317 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+
+	globals "github.com/almerlucke/glisp/globals/symbols"
+)
+
+// Sections of a lambda list, the sections must appear in this order
+const (
+	requiredSection = iota
+	optionalSection
+	restSection
+	keySection
+	otherKeysSection
+)
+
+// lambdaListKeywords maps the lambda list keywords to their section
+var lambdaListKeywords = map[*symbols.Symbol]int{
+	globals.AndOptionalSymbol:       optionalSection,
+	globals.AndRestSymbol:           restSection,
+	globals.AndBodySymbol:           restSection,
+	globals.AndKeySymbol:            keySection,
+	globals.AndAllowOtherKeysSymbol: otherKeysSection,
+}
+
+// lambdaListCompiler defines the parameters of a lambda list in the scope of
+// the body compiler. A destructuring lambda list can contain nested lambda
+// lists, a dotted rest parameter, &BODY and &WHOLE, a macro lambda list can
+// also contain &ENVIRONMENT
+type lambdaListCompiler struct {
+	name          string
+	c             environment.Compiler
+	defined       []*symbols.Symbol
+	destructuring bool
+	macro         bool
+}
+
+// define checks a parameter symbol and defines it, returns the slot of the
+// parameter
+func (lc *lambdaListCompiler) define(obj types.Object) (*symbols.Symbol, int, error) {
+	sym, ok := obj.(*symbols.Symbol)
+	if !ok {
+		return nil, 0, errors.Errorf(errors.TypeError, "%v arg list must contain only symbols", lc.name)
+	}
+
+	if sym.Reserved {
+		return nil, 0, errors.Errorf(errors.GeneralError, "%v arg list contains reserved symbol %v", lc.name, sym)
+	}
+
+	// Parameters are always bound lexically in the function scope, a special
+	// variable can't be a parameter because code called from the body would
+	// not see the binding. Bind it with LET or DYNAMIC-LET in the body instead
+	if sym.Special {
+		return nil, 0, errors.Errorf(errors.GeneralError, "%v arg list contains special symbol %v, bind it with LET or DYNAMIC-LET in the body", lc.name, sym)
+	}
+
+	for _, defined := range lc.defined {
+		if defined == sym {
+			return nil, 0, errors.Errorf(errors.GeneralError, "%v arg list contains duplicate symbol %v", lc.name, sym)
+		}
+	}
+
+	lc.defined = append(lc.defined, sym)
+	lc.c.Define(sym)
+
+	slot, _ := lc.c.Layout().Slot(sym)
+
+	return sym, slot, nil
+}
+
+// required compiles a required parameter, a symbol or in a destructuring
+// lambda list also a nested lambda list
+func (lc *lambdaListCompiler) required(obj types.Object) (*functions.Parameter, error) {
+	if lc.destructuring && obj.Type() == types.Cons {
+		pattern, err := lc.compile(obj, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return &functions.Parameter{Pattern: pattern}, nil
+	}
+
+	sym, slot, err := lc.define(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &functions.Parameter{Symbol: sym, Slot: slot}, nil
+}
+
+// parameter compiles an optional or key parameter, either a symbol or a
+// list of a symbol, a default form and a supplied-p symbol. The default
+// form can refer to the parameters before it. In a destructuring lambda
+// list the symbol can be a nested lambda list
+func (lc *lambdaListCompiler) parameter(obj types.Object) (*functions.Parameter, error) {
+	spec, ok := obj.(*cons.Cons)
+	if !ok {
+		return lc.required(obj)
+	}
+
+	_, length := spec.Info()
+	if length > 3 {
+		return nil, errors.Errorf(errors.GeneralError, "%v expected a parameter of the form (symbol default supplied-p)", lc.name)
+	}
+
+	p := &functions.Parameter{}
+
+	if length > 1 {
+		p.Default = lc.c.Compile(spec.Cdr.(*cons.Cons).Car)
+	}
+
+	variable, err := lc.required(spec.Car)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Symbol, p.Slot, p.Pattern = variable.Symbol, variable.Slot, variable.Pattern
+
+	if length > 2 {
+		p.Supplied, p.SuppliedSlot, err = lc.define(spec.Cdr.(*cons.Cons).Cdr.(*cons.Cons).Car)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// marker compiles the symbol following &WHOLE or &ENVIRONMENT, &WHOLE
+// must be the first element of a lambda list and &ENVIRONMENT can only be
+// used in the top level lambda list of a macro
+func (lc *lambdaListCompiler) marker(marker *symbols.Symbol, obj types.Object, first bool, top bool) (*functions.Parameter, error) {
+	if !lc.destructuring {
+		return nil, errors.Errorf(errors.GeneralError, "%v arg list can't contain %v", lc.name, marker)
+	}
+
+	if marker == globals.AndWholeSymbol && !first {
+		return nil, errors.Errorf(errors.GeneralError, "%v arg list has misplaced %v", lc.name, marker)
+	}
+
+	if marker == globals.AndEnvironmentSymbol && (!lc.macro || !top) {
+		return nil, errors.Errorf(errors.GeneralError, "%v arg list can only contain %v in a macro lambda list", lc.name, marker)
+	}
+
+	if obj.Type() != types.Cons {
+		return nil, errors.Errorf(errors.GeneralError, "%v arg list expected a symbol after %v", lc.name, marker)
+	}
+
+	sym, slot, err := lc.define(obj.(*cons.Cons).Car)
+	if err != nil {
+		return nil, err
+	}
+
+	return &functions.Parameter{Symbol: sym, Slot: slot}, nil
+}
+
+// compile compiles a lambda list, top is false for a nested lambda list
+func (lc *lambdaListCompiler) compile(obj types.Object, top bool) (*functions.LambdaList, error) {
+	ll := &functions.LambdaList{}
+	section := requiredSection
+	first := true
+
+	if !top {
+		ll.Form = obj
+	}
+
+	for ; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		element := obj.(*cons.Cons).Car
+		sym, _ := element.(*symbols.Symbol)
+
+		if sym == globals.AndWholeSymbol || sym == globals.AndEnvironmentSymbol {
+			p, err := lc.marker(sym, obj.(*cons.Cons).Cdr, first, top)
+			if err != nil {
+				return nil, err
+			}
+
+			if sym == globals.AndWholeSymbol {
+				ll.Whole = p
+			} else if ll.Environment == nil {
+				ll.Environment = p
+			} else {
+				return nil, errors.Errorf(errors.GeneralError, "%v arg list has more than one %v", lc.name, sym)
+			}
+
+			obj = obj.(*cons.Cons).Cdr
+			first = false
+
+			continue
+		}
+
+		first = false
+
+		next, ok := lambdaListKeywords[sym]
+		if ok {
+			if sym == globals.AndBodySymbol && !lc.destructuring {
+				return nil, errors.Errorf(errors.GeneralError, "%v arg list can't contain %v", lc.name, sym)
+			}
+
+			if next <= section || (section == restSection && ll.Rest == nil) {
+				return nil, errors.Errorf(errors.GeneralError, "%v arg list has misplaced %v", lc.name, sym)
+			}
+
+			if next == otherKeysSection && section != keySection {
+				return nil, errors.Errorf(errors.GeneralError, "%v arg list has &ALLOW-OTHER-KEYS without &KEY", lc.name)
+			}
+
+			ll.HasKey = ll.HasKey || next == keySection
+			ll.AllowOtherKeys = next == otherKeysSection
+			section = next
+
+			continue
+		}
+
+		var p *functions.Parameter
+		var err error
+
+		switch section {
+		case requiredSection:
+			p, err = lc.required(element)
+			ll.Required = append(ll.Required, p)
+		case optionalSection:
+			p, err = lc.parameter(element)
+			ll.Optional = append(ll.Optional, p)
+		case restSection:
+			if ll.Rest != nil {
+				return nil, errors.Errorf(errors.GeneralError, "%v arg list expected a single symbol after &REST", lc.name)
+			}
+
+			p, err = lc.required(element)
+			ll.Rest = p
+		case keySection:
+			p, err = lc.parameter(element)
+			if err == nil {
+				if p.Symbol == nil {
+					return nil, errors.Errorf(errors.GeneralError, "%v arg list expected a symbol as key parameter", lc.name)
+				}
+
+				p.Keyword = lc.c.Environment().InternKeyword(p.Symbol.Name)
+			}
+
+			ll.Key = append(ll.Key, p)
+		case otherKeysSection:
+			err = errors.Errorf(errors.GeneralError, "%v arg list has parameters after &ALLOW-OTHER-KEYS", lc.name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if section == restSection && ll.Rest == nil {
+		return nil, errors.Errorf(errors.GeneralError, "%v arg list expected a symbol after &REST", lc.name)
+	}
+
+	if obj != types.NIL {
+		// A dotted lambda list (a b . rest) is the same as (a b &rest rest)
+		if !lc.destructuring {
+			return nil, errors.Errorf(errors.GeneralError, "%v arg list must be a pure list", lc.name)
+		}
+
+		if section > optionalSection {
+			return nil, errors.Errorf(errors.GeneralError, "%v arg list has misplaced dotted rest parameter", lc.name)
+		}
+
+		sym, slot, err := lc.define(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		ll.Rest = &functions.Parameter{Symbol: sym, Slot: slot}
+	}
+
+	return ll, nil
+}
+
+// compileLambdaList compiles the lambda list of LAMBDA, the parameters are
+// defined in the scope of c which must be the compiler of the body. A
+// lambda list has the form:
+// required... [&optional optional...] [&rest symbol] [&key key... [&allow-other-keys]]
+func compileLambdaList(name string, obj types.Object, c environment.Compiler) (*functions.LambdaList, error) {
+	return compileLambdaListWith(&lambdaListCompiler{name: name, c: c}, obj)
+}
+
+// compileDestructuringLambdaList compiles the lambda list of MACRO or
+// DESTRUCTURING-BIND, required and optional parameters can be nested lambda
+// lists and &BODY is the same as &REST. The lambda list can start with
+// &WHOLE symbol and end with a dotted rest parameter, a macro lambda list
+// can contain &ENVIRONMENT symbol
+func compileDestructuringLambdaList(name string, obj types.Object, c environment.Compiler, macro bool) (*functions.LambdaList, error) {
+	return compileLambdaListWith(&lambdaListCompiler{name: name, c: c, destructuring: true, macro: macro}, obj)
+}
+
+// compileLambdaListWith compiles a top level lambda list with lc
+func compileLambdaListWith(lc *lambdaListCompiler, obj types.Object) (*functions.LambdaList, error) {
+	argType := obj.Type()
+
+	// Arg list must be cons or nil
+	if argType != types.Cons && argType != types.Null {
+		return nil, errors.Errorf(errors.TypeError, "%v expected an arg list as first argument", lc.name)
+	}
+
+	ll, err := lc.compile(obj, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ll.Form = obj
+	ll.Slots = len(lc.c.Layout().Symbols)
+
+	return ll, nil
+}
@@ -0,0 +1,201 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// letBinding is a compiled binding of LET or LET*, special variables have no
+// reference and are rebound dynamically
+type letBinding struct {
+	sym   *symbols.Symbol
+	value environment.Closure
+	ref   environment.Reference
+}
+
+// bind binds val, the returned function restores the previous binding of a
+// special variable and is nil for a lexical variable
+func (b *letBinding) bind(val types.Object, env environment.Environment) func() {
+	if b.ref == nil {
+		return bindSpecials([]*symbols.Symbol{b.sym}, []types.Object{val}, env)
+	}
+
+	b.ref.Bind(env, val)
+
+	return nil
+}
+
+// parseLetBinding parses a binding of the form symbol, (symbol) or
+// (symbol value), the value form is NIL if not given
+func parseLetBinding(name string, obj types.Object) (*symbols.Symbol, types.Object, error) {
+	var form types.Object = types.NIL
+
+	if binding, ok := obj.(*cons.Cons); ok {
+		_, length := binding.Info()
+		if length > 2 {
+			return nil, nil, errors.Errorf(errors.GeneralError, "%v expected bindings of the form (symbol value)", name)
+		}
+
+		if length == 2 {
+			form = binding.Cdr.(*cons.Cons).Car
+		}
+
+		obj = binding.Car
+	}
+
+	sym, ok := obj.(*symbols.Symbol)
+	if !ok || sym.IsKeyword {
+		return nil, nil, errors.Errorf(errors.TypeError, "%v expected a symbol to bind, got %v", name, obj)
+	}
+
+	if sym.Reserved {
+		return nil, nil, errors.Errorf(errors.GeneralError, "%v can't bind reserved symbol %v", name, sym)
+	}
+
+	return sym, form, nil
+}
+
+// compileLet compiles the bindings and body of LET and LET*, for LET* each
+// value form is compiled in the new scope and can refer to the variables
+// bound before it
+func compileLet(name string, args *cons.Cons, sequential bool, c environment.Compiler) (environment.Closure, error) {
+	bindingsType := args.Car.Type()
+	if bindingsType != types.Cons && bindingsType != types.Null {
+		return nil, errors.Errorf(errors.TypeError, "%v expected a binding list as first argument", name)
+	}
+
+	bodyCompiler := c.NewScope()
+	bindings := []*letBinding{}
+	special := false
+
+	for obj := args.Car; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		sym, form, err := parseLetBinding(name, obj.(*cons.Cons).Car)
+		if err != nil {
+			return nil, err
+		}
+
+		if !sequential {
+			for _, b := range bindings {
+				if b.sym == sym {
+					return nil, errors.Errorf(errors.GeneralError, "%v binds symbol %v more than once", name, sym)
+				}
+			}
+		}
+
+		b := &letBinding{sym: sym}
+
+		if sequential {
+			b.value = bodyCompiler.Compile(form)
+		} else {
+			b.value = c.Compile(form)
+		}
+
+		if sym.Special {
+			special = true
+		} else {
+			b.ref = bodyCompiler.Define(sym)
+		}
+
+		bindings = append(bindings, b)
+	}
+
+	var body environment.Closure
+
+	if special {
+		// Dynamic bindings are restored when the body returns, so the body
+		// can't return a pending tail call
+		body = CompileProgn(args.Cdr, bodyCompiler)
+	} else if args.Cdr.Type() == types.Cons {
+		body = bodyCompiler.CompileBody(args.Cdr.(*cons.Cons))
+	} else {
+		body = bodyCompiler.CompileBody(nil)
+	}
+
+	layout := bodyCompiler.Layout()
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		restores := []func(){}
+
+		defer func() {
+			// Restore special variables in reverse order
+			for i := len(restores) - 1; i >= 0; i-- {
+				restores[i]()
+			}
+		}()
+
+		var vals []types.Object
+
+		if !sequential {
+			// Values are evaluated in the enclosing scope before any
+			// variable is bound
+			vals = make([]types.Object, len(bindings))
+
+			for i, b := range bindings {
+				val, err := b.value(env, context)
+				if err != nil {
+					return nil, err
+				}
+
+				vals[i] = val
+			}
+		}
+
+		// Push a new scope
+		env.PushScope(scope.New(layout, env.CurrentScope()))
+
+		// Make sure we pop the scope after completion
+		defer env.PopScope()
+
+		for i, b := range bindings {
+			var val types.Object
+
+			if sequential {
+				var err error
+
+				val, err = b.value(env, context)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				val = vals[i]
+			}
+
+			if restore := b.bind(val, env); restore != nil {
+				restores = append(restores, restore)
+			}
+		}
+
+		// Without special variables the last form is in tail position,
+		// arguments of a tail call are evaluated before the scope is popped
+		return body(env, context)
+	}, nil
+}
+
+// CompileLet compiles a let special form, the values are evaluated before
+// the variables are bound in a new scope. Special variables are rebound for
+// the dynamic extent of the body
+func CompileLet(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileLet("LET", args, false, c)
+}
+
+// CompileLetStar compiles a let* special form, the variables are bound one
+// after the other so a value can refer to the variables bound before it
+func CompileLetStar(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileLet("LET*", args, true, c)
+}
+
+// CreateBuiltinLet creates a builtin function object
+func CreateBuiltinLet() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileLet, 1, function.Variadic)
+}
+
+// CreateBuiltinLetStar creates a builtin function object
+func CreateBuiltinLetStar() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileLetStar, 1, function.Variadic)
+}
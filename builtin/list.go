@@ -2,6 +2,7 @@ package builtin
 
 import (
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
 	"github.com/almerlucke/glisp/types/functions"
@@ -18,5 +19,5 @@ func List(args *cons.Cons, env environment.Environment, context interface{}) (ty
 
 // CreateBuiltinList creates a builtin function object
 func CreateBuiltinList() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(List, 0, true)
+	return functions.NewBuiltinFunction(List, 0, function.Variadic, true)
 }
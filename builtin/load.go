@@ -2,7 +2,6 @@ package builtin
 
 import (
 	"bufio"
-	"errors"
 	"io"
 	"os"
 
@@ -12,6 +11,7 @@ import (
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 	"github.com/almerlucke/glisp/types/strings"
 )
@@ -19,7 +19,7 @@ import (
 // Load builtin function
 func Load(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	if args.Car.Type() != types.String {
-		return nil, errors.New("LOAD expected a path string as first argument")
+		return nil, errors.New(errors.TypeError, "LOAD expected a path string as first argument")
 	}
 
 	path := args.Car.(strings.String)
@@ -31,18 +31,31 @@ func Load(args *cons.Cons, env environment.Environment, context interface{}) (ty
 
 	defer f.Close()
 
-	readTable := tables.DefaultReadTable
-	dispatchTable := tables.DefaultDispatchTable
+	rd := defaultReader.New(bufio.NewReader(f), tables.DefaultReadTable, tables.DefaultDispatchTable, env)
+	rd.SetFile(string(path))
 
-	rd := defaultReader.New(bufio.NewReader(f), readTable, dispatchTable, env)
+	return LoadReader(rd, env, context)
+}
 
+// LoadReader reads and evaluates all objects from rd and returns the result of
+// the last evaluation, or NIL if rd holds no objects
+func LoadReader(rd *defaultReader.Reader, env environment.Environment, context interface{}) (types.Object, error) {
 	obj, err := rd.ReadObject()
 	var result types.Object
 
 	for err == nil {
-		result, err = env.Eval(obj, context)
+		err = env.Step()
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = functions.Nested(env, func() (types.Object, error) {
+			return env.Eval(obj, context)
+		})
 		if err != nil {
-			return nil, rd.ErrorWithError(err)
+			// Errors in lists already report the position of the
+			// offending form
+			return nil, errors.WithPosition(err, rd.Position())
 		}
 
 		obj, err = rd.ReadObject()
@@ -61,5 +74,5 @@ func Load(args *cons.Cons, env environment.Environment, context interface{}) (ty
 
 // CreateBuiltinLoad creates a builtin function object
 func CreateBuiltinLoad() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Load, 0, true)
+	return functions.NewBuiltinFunction(Load, 1, 1, true)
 }
@@ -2,17 +2,31 @@ package loops
 
 import (
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
-// Break builtin function
+// Break builtin function, the innermost loop returns the optional argument
+// or NIL
 func Break(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	panic(&loopContext{})
+	if !env.HasDepthContext(loopDepth) {
+		return nil, errors.New(errors.GeneralError, "BREAK can only be used inside a loop")
+	}
+
+	var result types.Object = types.NIL
+	if args != nil {
+		result = args.Car
+	}
+
+	function.ExitTo(breakPoint, result)
+
+	return nil, nil
 }
 
 // CreateBuiltinBreak creates a builtin function object
 func CreateBuiltinBreak() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Break, 0, false)
+	return functions.NewBuiltinFunction(Break, 0, 1, true)
 }
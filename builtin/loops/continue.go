@@ -0,0 +1,27 @@
+package loops
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+)
+
+// Continue builtin function, ends the current iteration of the innermost
+// loop
+func Continue(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	if !env.HasDepthContext(loopDepth) {
+		return nil, errors.New(errors.GeneralError, "CONTINUE can only be used inside a loop")
+	}
+
+	function.ExitTo(continuePoint, types.NIL)
+
+	return nil, nil
+}
+
+// CreateBuiltinContinue creates a builtin function object
+func CreateBuiltinContinue() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(Continue, 0, 0, false)
+}
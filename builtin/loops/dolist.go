@@ -0,0 +1,40 @@
+package loops
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+)
+
+// CompileDolist compiles a dolist special form of the form
+// (dolist (var list [result]) body...), var is bound to each element of
+// the list and to NIL when the result form is evaluated
+func CompileDolist(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	it, err := compileIteration("DOLIST", args, false, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return it.closure(func(obj types.Object, step stepFun) (types.Object, error) {
+		if obj.Type() != types.Cons && obj.Type() != types.Null {
+			return nil, errors.Errorf(errors.TypeError, "DOLIST expected a list, got %v", obj)
+		}
+
+		for ; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+			err := step(obj.(*cons.Cons).Car, types.NIL)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return types.NIL, nil
+	}), nil
+}
+
+// CreateBuiltinDolist creates a builtin function object
+func CreateBuiltinDolist() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDolist, 1, function.Variadic)
+}
@@ -0,0 +1,59 @@
+package loops
+
+import (
+	"github.com/almerlucke/glisp/interfaces/collection"
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/numbers"
+)
+
+// CompileDoseq compiles a doseq special form of the form
+// (doseq (var collection [result]) body...) or
+// (doseq ((var index) collection [result]) body...), var is bound to each
+// element of the collection and index to its index or dictionary key. Var
+// is bound to NIL when the result form is evaluated
+func CompileDoseq(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	it, err := compileIteration("DOSEQ", args, true, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return it.closure(func(obj types.Object, step stepFun) (types.Object, error) {
+		if obj == types.NIL {
+			return types.NIL, nil
+		}
+
+		col, ok := obj.(collection.Collection)
+		if !ok {
+			return nil, errors.Errorf(errors.TypeError, "DOSEQ expected a collection, got %v", obj)
+		}
+
+		err := col.Iter(func(val types.Object, index interface{}) (bool, error) {
+			objIndex, ok := index.(types.Object)
+			if !ok {
+				intIndex, ok := index.(uint64)
+				if ok {
+					objIndex = numbers.NewUint64(intIndex)
+				} else {
+					objIndex = types.NIL
+				}
+			}
+
+			return false, step(val, objIndex)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NIL, nil
+	}), nil
+}
+
+// CreateBuiltinDoseq creates a builtin function object
+func CreateBuiltinDoseq() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDoseq, 1, function.Variadic)
+}
@@ -0,0 +1,48 @@
+package loops
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/numbers"
+)
+
+// CompileDotimes compiles a dotimes special form of the form
+// (dotimes (var count [result]) body...), var is bound to the integers from
+// zero up to count with the same number type as count and to count when
+// the result form is evaluated
+func CompileDotimes(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	it, err := compileIteration("DOTIMES", args, false, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return it.closure(func(obj types.Object, step stepFun) (types.Object, error) {
+		count, ok := obj.(*numbers.Number)
+		if !ok || !count.IsInteger() {
+			return nil, errors.Errorf(errors.TypeError, "DOTIMES expected an integer count, got %v", obj)
+		}
+
+		n := count.Int64Value()
+
+		for i := int64(0); i < n; i++ {
+			val := numbers.New(count.Kind)
+			val.SetInt64Value(i)
+
+			err := step(val, types.NIL)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return count, nil
+	}), nil
+}
+
+// CreateBuiltinDotimes creates a builtin function object
+func CreateBuiltinDotimes() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDotimes, 1, function.Variadic)
+}
@@ -1,8 +1,207 @@
 package loops
 
+import (
+	"github.com/almerlucke/glisp/builtin"
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
 const (
 	loopDepth = "LoopDepth"
 )
 
-type loopContext struct {
+// breakPoint is the exit point of the innermost loop
+var breakPoint = &function.ExitPoint{Kind: "BREAK"}
+
+// continuePoint is the exit point of the current iteration of the innermost
+// loop
+var continuePoint = &function.ExitPoint{Kind: "CONTINUE"}
+
+// iterate evaluates the body of a loop once, a CONTINUE in the body ends
+// the iteration with NIL as result
+func iterate(body environment.Closure, env environment.Environment, context interface{}) (result types.Object, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = function.Landed(r, continuePoint), nil
+		}
+	}()
+
+	return body(env, context)
+}
+
+// landed recovers the exits of a loop, BREAK ends the loop with its value.
+// A CONTINUE outside of the body, in the condition or the result form, has
+// no iteration to end and is an error
+func landed(r interface{}) (types.Object, error) {
+	if exit, ok := r.(*function.Exit); ok && exit.Point == continuePoint {
+		return nil, errors.New(errors.GeneralError, "CONTINUE can only be used in the body of a loop")
+	}
+
+	return function.Landed(r, breakPoint), nil
+}
+
+// stepFun evaluates the body of an iteration form with val and index bound
+type stepFun func(val types.Object, index types.Object) error
+
+// iterFun calls step for each element of the object of an iteration form
+// and returns the value the variable is bound to for the result form
+type iterFun func(obj types.Object, step stepFun) (types.Object, error)
+
+// iteration is a compiled DOTIMES, DOLIST or DOSEQ form, index is nil if
+// the form binds no index variable
+type iteration struct {
+	val    environment.Reference
+	index  environment.Reference
+	form   environment.Closure
+	result environment.Closure
+	body   environment.Closure
+	layout *scope.Layout
+}
+
+// loopVariable checks if obj is a symbol that can be bound by a loop
+func loopVariable(name string, obj types.Object) (*symbols.Symbol, error) {
+	sym, ok := obj.(*symbols.Symbol)
+	if !ok || sym.IsKeyword {
+		return nil, errors.Errorf(errors.TypeError, "%v expected a symbol as variable, got %v", name, obj)
+	}
+
+	if sym.Reserved || sym.Special {
+		return nil, errors.Errorf(errors.GeneralError, "%v can't bind symbol %v", name, sym)
+	}
+
+	return sym, nil
+}
+
+// compileIteration compiles an iteration form, if withIndex is true the
+// variable can be a list of a value and an index variable. The form is
+// evaluated outside the scope of the variables
+func compileIteration(name string, args *cons.Cons, withIndex bool, c environment.Compiler) (*iteration, error) {
+	spec, ok := args.Car.(*cons.Cons)
+	if !ok || spec.Cdr.Type() != types.Cons {
+		return nil, errors.Errorf(errors.GeneralError, "%v expected a specification of the form (var form [result])", name)
+	}
+
+	_, length := spec.Info()
+	if length > 3 {
+		return nil, errors.Errorf(errors.GeneralError, "%v expected a specification of the form (var form [result])", name)
+	}
+
+	it := &iteration{
+		form: c.Compile(spec.Cdr.(*cons.Cons).Car),
+	}
+
+	bodyCompiler := c.NewScope()
+
+	vars := []types.Object{spec.Car}
+	if withIndex && spec.Car.Type() == types.Cons {
+		vars = []types.Object{}
+
+		for obj := spec.Car; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+			vars = append(vars, obj.(*cons.Cons).Car)
+		}
+
+		if len(vars) != 2 {
+			return nil, errors.Errorf(errors.GeneralError, "%v expected variables of the form (var index)", name)
+		}
+	}
+
+	syms := []*symbols.Symbol{}
+
+	for _, obj := range vars {
+		sym, err := loopVariable(name, obj)
+		if err != nil {
+			return nil, err
+		}
+
+		syms = append(syms, sym)
+	}
+
+	it.val = bodyCompiler.Define(syms[0])
+
+	if len(syms) > 1 {
+		if syms[1] == syms[0] {
+			return nil, errors.Errorf(errors.GeneralError, "%v binds symbol %v more than once", name, syms[0])
+		}
+
+		it.index = bodyCompiler.Define(syms[1])
+	}
+
+	if length == 3 {
+		it.result = bodyCompiler.Compile(spec.Cdr.(*cons.Cons).Cdr.(*cons.Cons).Car)
+	}
+
+	// The body is evaluated repeatedly so no form is in tail position
+	it.body = builtin.CompileProgn(args.Cdr, bodyCompiler)
+	it.layout = bodyCompiler.Layout()
+
+	return it, nil
+}
+
+// bind evaluates closure in a new scope with val and index bound
+func (it *iteration) bind(closure environment.Closure, val types.Object, index types.Object, env environment.Environment, context interface{}) (types.Object, error) {
+	// Push a new scope
+	env.PushScope(scope.New(it.layout, env.CurrentScope()))
+
+	// Make sure we pop the scope after completion
+	defer env.PopScope()
+
+	it.val.Bind(env, val)
+
+	if it.index != nil {
+		it.index.Bind(env, index)
+	}
+
+	return closure(env, context)
+}
+
+// closure returns the closure evaluating the iteration with iter, the
+// result of the iteration is the value of the result form or the value of
+// a BREAK in the body. Each iteration binds the variables in a new scope so
+// closures created by the body keep the values of their iteration
+func (it *iteration) closure(iter iterFun) environment.Closure {
+	body := func(env environment.Environment, context interface{}) (types.Object, error) {
+		return iterate(it.body, env, context)
+	}
+
+	return func(env environment.Environment, context interface{}) (result types.Object, err error) {
+		obj, err := it.form(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		env.PushDepthContext(loopDepth)
+
+		defer func() {
+			env.PopDepthContext(loopDepth)
+
+			if r := recover(); r != nil {
+				result, err = landed(r)
+			}
+		}()
+
+		last, err := iter(obj, func(val types.Object, index types.Object) error {
+			err := env.Step()
+			if err != nil {
+				return err
+			}
+
+			_, err = it.bind(body, val, index, env, context)
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if it.result == nil {
+			return types.NIL, nil
+		}
+
+		return it.bind(it.result, last, types.NIL, env, context)
+	}
 }
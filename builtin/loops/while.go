@@ -7,52 +7,55 @@ import (
 	"github.com/almerlucke/glisp/types/functions"
 )
 
-// While builtin function
-func While(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	condition := args.Car
-	var result types.Object = types.NIL
-	var body types.Object = types.NIL
+// CompileWhile compiles a while special form, the result is the value of
+// the last evaluation of the body or the value of a BREAK
+func CompileWhile(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	condition := c.Compile(args.Car)
+	body := c.Compile(types.NIL)
 
 	if args.Cdr.Type() == types.Cons {
-		body = args.Cdr.(*cons.Cons).Car
+		body = c.Compile(args.Cdr.(*cons.Cons).Car)
 	}
 
-	env.PushDepthContext(loopDepth)
+	return func(env environment.Environment, context interface{}) (result types.Object, err error) {
+		result = types.NIL
 
-	defer func() {
-		env.PopDepthContext(loopDepth)
+		env.PushDepthContext(loopDepth)
 
-		if r := recover(); r != nil {
-			_, ok := r.(*loopContext)
-			if ok {
-				// do nothing, just catch a break
-			} else {
-				// Continue to panic
-				panic(r)
+		defer func() {
+			env.PopDepthContext(loopDepth)
+
+			if r := recover(); r != nil {
+				result, err = landed(r)
 			}
-		}
-	}()
+		}()
 
-	for {
-		obj, err := env.Eval(condition, context)
-		if err != nil {
-			return nil, err
-		}
+		for {
+			err = env.Step()
+			if err != nil {
+				return nil, err
+			}
 
-		if obj == types.NIL {
-			break
-		}
+			obj, err := condition(env, context)
+			if err != nil {
+				return nil, err
+			}
+
+			if obj == types.NIL {
+				break
+			}
 
-		result, err = env.Eval(body, context)
-		if err != nil {
-			return nil, err
+			result, err = iterate(body, env, context)
+			if err != nil {
+				return nil, err
+			}
 		}
-	}
 
-	return result, nil
+		return result, nil
+	}, nil
 }
 
 // CreateBuiltinWhile creates a builtin function object
-func CreateBuiltinWhile() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(While, 1, false)
+func CreateBuiltinWhile() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileWhile, 1, 2)
 }
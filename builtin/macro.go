@@ -1,54 +1,27 @@
 package builtin
 
 import (
-	"errors"
-	"fmt"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
 	"github.com/almerlucke/glisp/types/functions"
-	"github.com/almerlucke/glisp/types/symbols"
 )
 
-// Macro buildin function
-func Macro(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	argType := args.Car.Type()
-
-	// Arg list must be cons or nil
-	if argType != types.Cons && argType != types.Null {
-		return nil, errors.New("MACRO expected an arg list as first argument")
-	}
-
-	symList := []*symbols.Symbol{}
-
-	if argType == types.Cons {
-		argList := args.Car.(*cons.Cons)
-		pure, length := argList.Info()
-		if !pure {
-			return nil, errors.New("MACRO arg list must be a pure list")
-		}
-
-		symList = make([]*symbols.Symbol, length)
-
-		// Build sym arg slice
-		err := argList.Iter(func(obj types.Object, index interface{}) (bool, error) {
-			if obj.Type() != types.Symbol {
-				return false, errors.New("MACRO arg list must contain only symbols")
-			}
-
-			if obj.(*symbols.Symbol).Reserved {
-				return false, fmt.Errorf("MACRO arg list contains reserved symbol %v", obj.(*symbols.Symbol))
-			}
-
-			symList[index.(uint64)] = obj.(*symbols.Symbol)
+// CompileMacro compiles a macro special form
+func CompileMacro(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return compileMacro("MACRO", args, nil, c)
+}
 
-			return false, nil
-		})
+// compileMacro compiles the lambda list and body of a macro function, the
+// description is nil for an anonymous macro
+func compileMacro(name string, args *cons.Cons, description *functions.Description, c environment.Compiler) (environment.Closure, error) {
+	// Arguments are bound to the first slots of the layout
+	bodyCompiler := c.NewScope()
 
-		if err != nil {
-			return nil, err
-		}
+	lambdaList, err := compileDestructuringLambdaList(name, args.Car, bodyCompiler, true)
+	if err != nil {
+		return nil, err
 	}
 
 	var body *cons.Cons
@@ -56,10 +29,17 @@ func Macro(args *cons.Cons, env environment.Environment, context interface{}) (t
 		body = args.Cdr.(*cons.Cons)
 	}
 
-	return functions.NewMacroFunction(symList, env.CaptureScope(), body), nil
+	layout := bodyCompiler.Layout()
+	compiledBody := bodyCompiler.CompileBody(body)
+
+	pos := c.Position()
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		return functions.NewMacroFunction(lambdaList, description, layout, env.CaptureScope(), compiledBody, pos), nil
+	}, nil
 }
 
 // CreateBuiltinMacro creates a builtin function object
-func CreateBuiltinMacro() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Macro, 1, false)
+func CreateBuiltinMacro() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileMacro, 1, function.Variadic)
 }
@@ -0,0 +1,350 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/environments"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+
+	globals "github.com/almerlucke/glisp/globals/symbols"
+)
+
+// expansionScope returns the scope macros are looked up in, the scope of the
+// optional environment argument or the current scope
+func expansionScope(name string, args *cons.Cons, env environment.Environment) (*scope.Scope, error) {
+	if args.Cdr.Type() != types.Cons {
+		return env.CurrentScope(), nil
+	}
+
+	e, ok := args.Cdr.(*cons.Cons).Car.(*environments.Environment)
+	if !ok {
+		return nil, errors.Errorf(errors.TypeError, "%v expected an environment as second argument", name)
+	}
+
+	return e.Scope, nil
+}
+
+// macroexpand1 expands form once if it is a macro call, returns the form
+// itself and false if it is not a macro call. Macros are looked up in s
+// before the global scope
+func macroexpand1(form types.Object, s *scope.Scope, env environment.Environment, context interface{}) (types.Object, bool, error) {
+	call, ok := form.(*cons.Cons)
+	if !ok {
+		return form, false, nil
+	}
+
+	sym, ok := call.Car.(*symbols.Symbol)
+	if !ok {
+		return form, false, nil
+	}
+
+	var obj types.Object
+
+	bindingScope, slot, ok := s.Lookup(sym)
+	if ok {
+		obj = bindingScope.Get(slot)
+	} else {
+		obj = env.GetGlobalBinding(sym)
+	}
+
+	// Symbols renamed by a hygienic expansion refer to their alias
+	for alias := sym.Alias; obj == nil && alias != nil; alias = alias.Alias {
+		obj = env.GetGlobalBinding(alias)
+	}
+
+	macro, ok := obj.(*functions.MacroFunction)
+	if !ok {
+		return form, false, nil
+	}
+
+	expanded, err := macro.Expand(call, env, context)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return expanded, true, nil
+}
+
+// macroexpand expands form until it is no longer a macro call
+func macroexpand(form types.Object, s *scope.Scope, env environment.Environment, context interface{}) (types.Object, error) {
+	for {
+		expanded, ok, err := macroexpand1(form, s, env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return form, nil
+		}
+
+		form = expanded
+	}
+}
+
+// allExpander expands all macro calls in evaluated positions of a form,
+// macros are looked up in s before the global scope
+type allExpander struct {
+	s       *scope.Scope
+	env     environment.Environment
+	context interface{}
+}
+
+// elementFun returns the expansion of the element at index of a list
+type elementFun func(index int, obj types.Object) (types.Object, error)
+
+// mapList returns a copy of list with each element replaced by the result of
+// f, the tail of a dotted list is kept
+func mapList(list types.Object, f elementFun) (types.Object, error) {
+	builder := cons.ListBuilder{}
+
+	obj := list
+	for index := 0; obj.Type() == types.Cons; index++ {
+		expanded, err := f(index, obj.(*cons.Cons).Car)
+		if err != nil {
+			return nil, err
+		}
+
+		builder.PushBackObject(expanded)
+
+		obj = obj.(*cons.Cons).Cdr
+	}
+
+	if builder.Head == nil {
+		return obj, nil
+	}
+
+	builder.Tail.Cdr = obj
+
+	return builder.Head, nil
+}
+
+// from returns an element function expanding the elements from index start
+// with f, the elements before start are expanded as forms
+func (x *allExpander) from(start int, f elementFun) elementFun {
+	return func(index int, obj types.Object) (types.Object, error) {
+		if index < start {
+			return x.expand(obj)
+		}
+
+		return f(index-start, obj)
+	}
+}
+
+// first returns an element function expanding the first element with f, the
+// other elements are expanded as forms
+func (x *allExpander) first(f elementFun) elementFun {
+	return func(index int, obj types.Object) (types.Object, error) {
+		if index == 0 {
+			return f(index, obj)
+		}
+
+		return x.expand(obj)
+	}
+}
+
+// keep returns obj unexpanded
+func keep(index int, obj types.Object) (types.Object, error) {
+	return obj, nil
+}
+
+// form expands obj as a form
+func (x *allExpander) form(index int, obj types.Object) (types.Object, error) {
+	return x.expand(obj)
+}
+
+// list returns an element function expanding an element which is a list
+// with f, other elements are kept
+func list(f elementFun) elementFun {
+	return func(index int, obj types.Object) (types.Object, error) {
+		if obj.Type() != types.Cons {
+			return obj, nil
+		}
+
+		return mapList(obj, f)
+	}
+}
+
+// lambdaList expands the default forms of a lambda list, nested lambda
+// lists of a destructuring lambda list are expanded the same way
+func (x *allExpander) lambdaList(index int, obj types.Object) (types.Object, error) {
+	parameters := false
+
+	return list(func(index int, obj types.Object) (types.Object, error) {
+		if sym, ok := obj.(*symbols.Symbol); ok {
+			if _, ok := lambdaListKeywords[sym]; ok || sym == globals.AndWholeSymbol || sym == globals.AndEnvironmentSymbol {
+				parameters = sym == globals.AndOptionalSymbol || sym == globals.AndKeySymbol
+			}
+
+			return obj, nil
+		}
+
+		if !parameters {
+			return x.lambdaList(index, obj)
+		}
+
+		// Parameter of the form (symbol default supplied-p)
+		return list(func(index int, obj types.Object) (types.Object, error) {
+			switch index {
+			case 0:
+				return x.lambdaList(index, obj)
+			case 1:
+				return x.expand(obj)
+			}
+
+			return obj, nil
+		})(index, obj)
+	})(index, obj)
+}
+
+// lambda expands a list of the form (lambda-list body...)
+func (x *allExpander) lambda(index int, obj types.Object) (types.Object, error) {
+	if index == 0 {
+		return x.lambdaList(index, obj)
+	}
+
+	return x.expand(obj)
+}
+
+// clause expands a list of the form (name lambda-list body...)
+func (x *allExpander) clause(index int, obj types.Object) (types.Object, error) {
+	if index == 0 {
+		return obj, nil
+	}
+
+	return x.lambda(index-1, obj)
+}
+
+// specialForm returns the element function for the arguments of a special
+// form binding variables or holding data, nil for other forms whose elements
+// are all expanded as forms
+func (x *allExpander) specialForm(head types.Object) elementFun {
+	switch {
+	case isForm(head, "LAMBDA", "MACRO", "DESTRUCTURING-BIND"):
+		// (lambda lambda-list body...)
+		return x.lambda
+	case isForm(head, "DEFUN", "DEFMACRO"):
+		// (defun name lambda-list body...)
+		return x.clause
+	case isForm(head, "LET", "LET*", "DYNAMIC-LET"):
+		// (let ((symbol value)...) body...)
+		return x.first(list(list(x.first(keep))))
+	case isForm(head, "FLET", "LABELS"):
+		// (flet ((name lambda-list body...)...) body...)
+		return x.first(list(list(x.clause)))
+	case isForm(head, "DOTIMES", "DOLIST", "DOSEQ"):
+		// (dolist (var form [result]) body...)
+		return x.first(list(x.first(keep)))
+	case isForm(head, caseForms...):
+		// (case keyform (keys body...)...)
+		return x.from(1, list(x.first(keep)))
+	case isForm(head, "COND"):
+		// (cond (test body...)...)
+		return list(x.form)
+	case isForm(head, "HANDLER-CASE", "RESTART-CASE"):
+		// (handler-case form (name lambda-list body...)...)
+		return x.from(1, list(x.clause))
+	case isForm(head, "TRY"):
+		// (try form ((selector (arg) body...)...)) or (try form function)
+		return x.from(1, func(index int, obj types.Object) (types.Object, error) {
+			if obj.Type() == types.Cons && obj.(*cons.Cons).Car.Type() == types.Cons {
+				return list(list(x.from(1, x.lambda)))(index, obj)
+			}
+
+			return x.expand(obj)
+		})
+	}
+
+	return nil
+}
+
+// expand expands form and the subforms in evaluated positions, quoted and
+// backquoted forms, lambda lists, binding lists and CASE keys are not
+// expanded
+func (x *allExpander) expand(form types.Object) (types.Object, error) {
+	form, err := macroexpand(form, x.s, x.env, x.context)
+	if err != nil {
+		return nil, err
+	}
+
+	call, ok := form.(*cons.Cons)
+	if !ok || call.Car == globals.QuoteSymbol || call.Car == globals.BackquoteSymbol {
+		return form, nil
+	}
+
+	f := x.specialForm(call.Car)
+	if f == nil {
+		return mapList(call, x.form)
+	}
+
+	args, err := mapList(call.Cdr, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cons.Cons{Car: call.Car, Cdr: args}, nil
+}
+
+// macroexpandAll expands form and all its subforms in evaluated positions
+func macroexpandAll(form types.Object, s *scope.Scope, env environment.Environment, context interface{}) (types.Object, error) {
+	x := &allExpander{
+		s:       s,
+		env:     env,
+		context: context,
+	}
+
+	return x.expand(form)
+}
+
+// Macroexpand1 builtin function, expands a macro call form once, other forms
+// are returned as is. Macros are looked up in the optional environment
+func Macroexpand1(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	s, err := expansionScope("MACROEXPAND-1", args, env)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, _, err := macroexpand1(args.Car, s, env, context)
+
+	return expanded, err
+}
+
+// Macroexpand builtin function, expands a form until it is no longer a macro
+// call form
+func Macroexpand(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	s, err := expansionScope("MACROEXPAND", args, env)
+	if err != nil {
+		return nil, err
+	}
+
+	return macroexpand(args.Car, s, env, context)
+}
+
+// MacroexpandAll builtin function, expands a form and all its subforms in
+// evaluated positions
+func MacroexpandAll(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	s, err := expansionScope("MACROEXPAND-ALL", args, env)
+	if err != nil {
+		return nil, err
+	}
+
+	return macroexpandAll(args.Car, s, env, context)
+}
+
+// CreateBuiltinMacroexpand1 creates a builtin function object
+func CreateBuiltinMacroexpand1() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(Macroexpand1, 1, 2, true)
+}
+
+// CreateBuiltinMacroexpand creates a builtin function object
+func CreateBuiltinMacroexpand() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(Macroexpand, 1, 2, true)
+}
+
+// CreateBuiltinMacroexpandAll creates a builtin function object
+func CreateBuiltinMacroexpandAll() *functions.BuiltinFunction {
+	return functions.NewBuiltinFunction(MacroexpandAll, 1, 2, true)
+}
@@ -1,30 +1,36 @@
 package builtin
 
 import (
-	"errors"
-
 	"github.com/almerlucke/glisp/interfaces/collection"
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 	"github.com/almerlucke/glisp/types/numbers"
 )
 
-// Map builtin function
+// Map builtin function, the function is called with each element and its
+// index if it accepts two arguments, otherwise only with the element
 func Map(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	col, ok := args.Car.(collection.Collection)
 	if !ok {
-		return nil, errors.New("MAP expected a collection as first argument")
+		return nil, errors.New(errors.TypeError, "MAP expected a collection as first argument")
 	}
 
 	fun, ok := args.Cdr.(*cons.Cons).Car.(function.Function)
 	if !ok {
-		return nil, errors.New("MAP expected a function as second argument")
+		return nil, errors.New(errors.TypeError, "MAP expected a function as second argument")
 	}
 
+	withIndex := fun.MaxArgs() == function.Variadic || fun.MaxArgs() >= 2
+
 	newCol, err := col.Map(func(obj types.Object, index interface{}) (types.Object, error) {
+		if !withIndex {
+			return functions.Apply(fun, cons.ListFromSlice([]types.Object{obj}), env, context)
+		}
+
 		objIndex, ok := index.(types.Object)
 		if !ok {
 			intIndex, ok := index.(uint64)
@@ -35,7 +41,7 @@ func Map(args *cons.Cons, env environment.Environment, context interface{}) (typ
 			}
 		}
 
-		return fun.Eval(cons.ListFromSlice([]types.Object{obj, objIndex}), env, context)
+		return functions.Apply(fun, cons.ListFromSlice([]types.Object{obj, objIndex}), env, context)
 	})
 
 	return newCol, err
@@ -43,5 +49,5 @@ func Map(args *cons.Cons, env environment.Environment, context interface{}) (typ
 
 // CreateBuiltinMap creates a builtin function object
 func CreateBuiltinMap() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Map, 2, true)
+	return functions.NewBuiltinFunction(Map, 2, 2, true)
 }
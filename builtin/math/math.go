@@ -1,14 +1,13 @@
 package math
 
 import (
-	"errors"
-	"fmt"
 	"math"
 	"reflect"
 
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/numbers"
 )
 
@@ -63,7 +62,7 @@ func genericSingleArgMathFunc(num *numbers.Number, fun func(float64) float64, en
 func singleFloat64MathFunc(obj types.Object, name string, fun func(float64) float64) (types.Object, error) {
 	num, ok := obj.(*numbers.Number)
 	if !ok {
-		return nil, fmt.Errorf("%v only accepts numbers", name)
+		return nil, errors.Errorf(errors.TypeError, "%v only accepts numbers", name)
 	}
 
 	newNum := numbers.New(reflect.Float64)
@@ -75,12 +74,12 @@ func singleFloat64MathFunc(obj types.Object, name string, fun func(float64) floa
 func doubleFloat64MathFunc(obj1 types.Object, obj2 types.Object, name string, fun func(float64, float64) float64) (types.Object, error) {
 	num1, ok := obj1.(*numbers.Number)
 	if !ok {
-		return nil, fmt.Errorf("%v only accepts numbers", name)
+		return nil, errors.Errorf(errors.TypeError, "%v only accepts numbers", name)
 	}
 
 	num2, ok := obj2.(*numbers.Number)
 	if !ok {
-		return nil, fmt.Errorf("%v only accepts numbers", name)
+		return nil, errors.Errorf(errors.TypeError, "%v only accepts numbers", name)
 	}
 
 	newNum := numbers.New(reflect.Float64)
@@ -92,12 +91,12 @@ func doubleFloat64MathFunc(obj1 types.Object, obj2 types.Object, name string, fu
 func doubleFloat32MathFunc(obj1 types.Object, obj2 types.Object, name string, fun func(float32, float32) float32) (types.Object, error) {
 	num1, ok := obj1.(*numbers.Number)
 	if !ok {
-		return nil, fmt.Errorf("%v only accepts numbers", name)
+		return nil, errors.Errorf(errors.TypeError, "%v only accepts numbers", name)
 	}
 
 	num2, ok := obj2.(*numbers.Number)
 	if !ok {
-		return nil, fmt.Errorf("%v only accepts numbers", name)
+		return nil, errors.Errorf(errors.TypeError, "%v only accepts numbers", name)
 	}
 
 	newNum := numbers.New(reflect.Float32)
@@ -110,7 +109,7 @@ func doubleFloat32MathFunc(obj1 types.Object, obj2 types.Object, name string, fu
 func Abs(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("ABS only accepts numbers")
+		return nil, errors.New(errors.TypeError, "ABS only accepts numbers")
 	}
 
 	return genericSingleArgMathFunc(num, math.Abs, env, context)
@@ -210,11 +209,11 @@ func Expm1(args *cons.Cons, env environment.Environment, context interface{}) (t
 func Float32Bits(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("FLOAT32-BITS only accepts numbers")
+		return nil, errors.New(errors.TypeError, "FLOAT32-BITS only accepts numbers")
 	}
 
 	if num.Kind != reflect.Float32 {
-		return nil, errors.New("FLOAT32-BITS expected a float32 number")
+		return nil, errors.New(errors.TypeError, "FLOAT32-BITS expected a float32 number")
 	}
 
 	newNum := numbers.New(reflect.Uint32)
@@ -227,11 +226,11 @@ func Float32Bits(args *cons.Cons, env environment.Environment, context interface
 func Float32FromBits(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("FLOAT32-FROM-BITS only accepts numbers")
+		return nil, errors.New(errors.TypeError, "FLOAT32-FROM-BITS only accepts numbers")
 	}
 
 	if num.Kind != reflect.Uint32 {
-		return nil, errors.New("FLOAT32-FROM-BITS expected a uint32 number")
+		return nil, errors.New(errors.TypeError, "FLOAT32-FROM-BITS expected a uint32 number")
 	}
 
 	newNum := numbers.New(reflect.Float32)
@@ -244,11 +243,11 @@ func Float32FromBits(args *cons.Cons, env environment.Environment, context inter
 func Float64Bits(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("FLOAT64-BITS only accepts numbers")
+		return nil, errors.New(errors.TypeError, "FLOAT64-BITS only accepts numbers")
 	}
 
 	if num.Kind != reflect.Float64 {
-		return nil, errors.New("FLOAT64-BITS expected a float64 number")
+		return nil, errors.New(errors.TypeError, "FLOAT64-BITS expected a float64 number")
 	}
 
 	newNum := numbers.New(reflect.Uint64)
@@ -261,11 +260,11 @@ func Float64Bits(args *cons.Cons, env environment.Environment, context interface
 func Float64FromBits(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("FLOAT64-FROM-BITS only accepts numbers")
+		return nil, errors.New(errors.TypeError, "FLOAT64-FROM-BITS only accepts numbers")
 	}
 
 	if num.Kind != reflect.Uint64 {
-		return nil, errors.New("FLOAT64-FROM-BITS expected a uint64 number")
+		return nil, errors.New(errors.TypeError, "FLOAT64-FROM-BITS expected a uint64 number")
 	}
 
 	newNum := numbers.New(reflect.Float64)
@@ -283,7 +282,7 @@ func Floor(args *cons.Cons, env environment.Environment, context interface{}) (t
 func Frexp(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("FREXP only accepts numbers")
+		return nil, errors.New(errors.TypeError, "FREXP only accepts numbers")
 	}
 
 	fl := num.Float64Value()
@@ -312,7 +311,7 @@ func Hypot(args *cons.Cons, env environment.Environment, context interface{}) (t
 func Ilogb(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("ILOGB only accepts numbers")
+		return nil, errors.New(errors.TypeError, "ILOGB only accepts numbers")
 	}
 
 	fl := num.Float64Value()
@@ -328,7 +327,7 @@ func Ilogb(args *cons.Cons, env environment.Environment, context interface{}) (t
 func Inf(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("INF only accepts numbers")
+		return nil, errors.New(errors.TypeError, "INF only accepts numbers")
 	}
 
 	fl := num.Int64Value()
@@ -343,12 +342,12 @@ func Inf(args *cons.Cons, env environment.Environment, context interface{}) (typ
 func IsInf(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num1, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("IS-INF only accepts numbers")
+		return nil, errors.New(errors.TypeError, "IS-INF only accepts numbers")
 	}
 
 	num2, ok := args.Cdr.(*cons.Cons).Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("IS-INF only accepts numbers")
+		return nil, errors.New(errors.TypeError, "IS-INF only accepts numbers")
 	}
 
 	fl := num1.Float64Value()
@@ -365,7 +364,7 @@ func IsInf(args *cons.Cons, env environment.Environment, context interface{}) (t
 func IsNaN(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("IS-NAN only accepts numbers")
+		return nil, errors.New(errors.TypeError, "IS-NAN only accepts numbers")
 	}
 
 	fl := num.Float64Value()
@@ -391,12 +390,12 @@ func J1(args *cons.Cons, env environment.Environment, context interface{}) (type
 func Jn(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num1, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("JN only accepts numbers")
+		return nil, errors.New(errors.TypeError, "JN only accepts numbers")
 	}
 
 	num2, ok := args.Cdr.(*cons.Cons).Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("JN only accepts numbers")
+		return nil, errors.New(errors.TypeError, "JN only accepts numbers")
 	}
 
 	n := num1.Int64Value()
@@ -412,12 +411,12 @@ func Jn(args *cons.Cons, env environment.Environment, context interface{}) (type
 func Ldexp(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num1, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("LDEXP only accepts numbers")
+		return nil, errors.New(errors.TypeError, "LDEXP only accepts numbers")
 	}
 
 	num2, ok := args.Cdr.(*cons.Cons).Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("LDEXP only accepts numbers")
+		return nil, errors.New(errors.TypeError, "LDEXP only accepts numbers")
 	}
 
 	frac := num1.Float64Value()
@@ -433,7 +432,7 @@ func Ldexp(args *cons.Cons, env environment.Environment, context interface{}) (t
 func Lgamma(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("LGAMMA only accepts numbers")
+		return nil, errors.New(errors.TypeError, "LGAMMA only accepts numbers")
 	}
 
 	fl := num.Float64Value()
@@ -477,7 +476,7 @@ func Logb(args *cons.Cons, env environment.Environment, context interface{}) (ty
 func Modf(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("MODF only accepts numbers")
+		return nil, errors.New(errors.TypeError, "MODF only accepts numbers")
 	}
 
 	fl := num.Float64Value()
@@ -518,7 +517,7 @@ func Pow(args *cons.Cons, env environment.Environment, context interface{}) (typ
 func Pow10(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("POW10 only accepts numbers")
+		return nil, errors.New(errors.TypeError, "POW10 only accepts numbers")
 	}
 
 	n := num.Int64Value()
@@ -538,7 +537,7 @@ func Remainder(args *cons.Cons, env environment.Environment, context interface{}
 func Signbit(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("SIGNBIT only accepts numbers")
+		return nil, errors.New(errors.TypeError, "SIGNBIT only accepts numbers")
 	}
 
 	if math.Signbit(num.Float64Value()) {
@@ -557,7 +556,7 @@ func Sin(args *cons.Cons, env environment.Environment, context interface{}) (typ
 func Sincos(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("SINCOS only accepts numbers")
+		return nil, errors.New(errors.TypeError, "SINCOS only accepts numbers")
 	}
 
 	fl := num.Float64Value()
@@ -611,12 +610,12 @@ func Y1(args *cons.Cons, env environment.Environment, context interface{}) (type
 func Yn(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num1, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("YN only accepts numbers")
+		return nil, errors.New(errors.TypeError, "YN only accepts numbers")
 	}
 
 	num2, ok := args.Cdr.(*cons.Cons).Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("YN only accepts numbers")
+		return nil, errors.New(errors.TypeError, "YN only accepts numbers")
 	}
 
 	n := num1.Int64Value()
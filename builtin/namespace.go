@@ -1,15 +1,14 @@
 package builtin
 
 import (
-	"errors"
-	"fmt"
-
 	goStrings "strings"
 
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/interfaces/namespace"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 	"github.com/almerlucke/glisp/types/namespaces"
 	"github.com/almerlucke/glisp/types/strings"
@@ -25,7 +24,7 @@ func namespaceGetName(obj types.Object) (string, error) {
 		return goStrings.ToUpper(string(obj.(strings.String))), nil
 	}
 
-	return "", errors.New("NAMESPACE expected a string or symbol")
+	return "", errors.New(errors.TypeError, "NAMESPACE expected a string or symbol")
 }
 
 func namespaceClauseContainsOnlyNames(c *cons.Cons) bool {
@@ -50,7 +49,7 @@ func importSymbolsFromOtherNamespace(ns namespace.Namespace, clause *cons.Cons,
 	otherNS := env.FindNamespace(nsName)
 
 	if otherNS == nil {
-		return fmt.Errorf("IMPORT-FROM undefined namespace %v", nsName)
+		return errors.Errorf(errors.GeneralError, "IMPORT-FROM undefined namespace %v", nsName)
 	}
 
 	if clause.Cdr.Type() != types.Cons {
@@ -62,7 +61,7 @@ func importSymbolsFromOtherNamespace(ns namespace.Namespace, clause *cons.Cons,
 		success := ns.Import(name, otherNS)
 
 		if !success {
-			return false, fmt.Errorf("IMPORT-FROM namespace %v unknown symbol %v", nsName, name)
+			return false, errors.Errorf(errors.GeneralError, "IMPORT-FROM namespace %v unknown symbol %v", nsName, name)
 		}
 
 		return false, nil
@@ -95,7 +94,7 @@ func useOtherNamespaces(ns namespace.Namespace, clause *cons.Cons, env environme
 		otherNS := env.FindNamespace(name)
 
 		if otherNS == nil {
-			return false, fmt.Errorf("undefined namespace %v", name)
+			return false, errors.Errorf(errors.GeneralError, "undefined namespace %v", name)
 		}
 
 		ns.Use(otherNS)
@@ -116,13 +115,13 @@ func Namespace(args *cons.Cons, env environment.Environment, context interface{}
 	if args.Cdr.Type() == types.Cons {
 		err = args.Cdr.(*cons.Cons).Iter(func(obj types.Object, index interface{}) (bool, error) {
 			if obj.Type() != types.Cons {
-				return false, errors.New("illegal namespace clause")
+				return false, errors.New(errors.GeneralError, "illegal namespace clause")
 			}
 
 			clause := obj.(*cons.Cons)
 
 			if !namespaceClauseContainsOnlyNames(clause) {
-				return false, errors.New("namespace clause must contains only names")
+				return false, errors.New(errors.GeneralError, "namespace clause must contains only names")
 			}
 
 			name, _ := namespaceGetName(clause.Car)
@@ -175,11 +174,11 @@ func InNamespace(args *cons.Cons, env environment.Environment, context interface
 
 	ns := env.FindNamespace(name)
 	if ns == nil {
-		return nil, fmt.Errorf("undefined namespace %v", name)
+		return nil, errors.Errorf(errors.GeneralError, "undefined namespace %v", name)
 	}
 
 	if !ns.CanIntern() {
-		return nil, fmt.Errorf("namespace %v is locked", name)
+		return nil, errors.Errorf(errors.GeneralError, "namespace %v is locked", name)
 	}
 
 	env.ChangeCurrentNamespace(name)
@@ -196,7 +195,7 @@ func UseNamespace(args *cons.Cons, env environment.Environment, context interfac
 
 	ns := env.FindNamespace(name)
 	if ns == nil {
-		return nil, fmt.Errorf("undefined namespace %v", name)
+		return nil, errors.Errorf(errors.GeneralError, "undefined namespace %v", name)
 	}
 
 	curNS := env.CurrentNamespace()
@@ -208,15 +207,15 @@ func UseNamespace(args *cons.Cons, env environment.Environment, context interfac
 
 // CreateBuiltinNamespace creates a builtin function object
 func CreateBuiltinNamespace() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Namespace, 1, false)
+	return functions.NewBuiltinFunction(Namespace, 1, function.Variadic, false)
 }
 
 // CreateBuiltinInNamespace creates a builtin function object
 func CreateBuiltinInNamespace() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(InNamespace, 1, false)
+	return functions.NewBuiltinFunction(InNamespace, 1, 1, false)
 }
 
 // CreateBuiltinUseNamespace creates a builtin function object
 func CreateBuiltinUseNamespace() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(UseNamespace, 1, false)
+	return functions.NewBuiltinFunction(UseNamespace, 1, 1, false)
 }
@@ -18,5 +18,5 @@ func Not(args *cons.Cons, env environment.Environment, context interface{}) (typ
 
 // CreateBuiltinNot creates a builtin function object
 func CreateBuiltinNot() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Not, 1, true)
+	return functions.NewBuiltinFunction(Not, 1, 1, true)
 }
@@ -1,11 +1,11 @@
 package numbers
 
 import (
-	"errors"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 	"github.com/almerlucke/glisp/types/numbers"
 )
@@ -18,7 +18,7 @@ func NumberAdd(args *cons.Cons, env environment.Environment, context interface{}
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New("+ only accepts numbers")
+			return false, errors.New(errors.TypeError, "+ only accepts numbers")
 		}
 
 		if total == nil {
@@ -48,7 +48,7 @@ func NumberSubtract(args *cons.Cons, env environment.Environment, context interf
 	if args.Length() == 1 {
 		num, ok := args.Car.(*numbers.Number)
 		if !ok {
-			return nil, errors.New("- only accepts numbers")
+			return nil, errors.New(errors.TypeError, "- only accepts numbers")
 		}
 
 		return numbers.New(num.Kind).Subtract(num)
@@ -57,7 +57,7 @@ func NumberSubtract(args *cons.Cons, env environment.Environment, context interf
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New("- only accepts numbers")
+			return false, errors.New(errors.TypeError, "- only accepts numbers")
 		}
 
 		if total == nil {
@@ -87,7 +87,7 @@ func NumberMultiply(args *cons.Cons, env environment.Environment, context interf
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New("* only accepts numbers")
+			return false, errors.New(errors.TypeError, "* only accepts numbers")
 		}
 
 		if total == nil {
@@ -117,7 +117,7 @@ func NumberDivide(args *cons.Cons, env environment.Environment, context interfac
 	if args.Length() == 1 {
 		num, ok := args.Car.(*numbers.Number)
 		if !ok {
-			return nil, errors.New("- only accepts numbers")
+			return nil, errors.New(errors.TypeError, "- only accepts numbers")
 		}
 
 		otherNum := numbers.New(num.Kind)
@@ -129,7 +129,7 @@ func NumberDivide(args *cons.Cons, env environment.Environment, context interfac
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New("/ only accepts numbers")
+			return false, errors.New(errors.TypeError, "/ only accepts numbers")
 		}
 
 		if total == nil {
@@ -155,12 +155,12 @@ func NumberDivide(args *cons.Cons, env environment.Environment, context interfac
 func NumberModulo(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	num1, ok := args.Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("% only accepts numbers")
+		return nil, errors.New(errors.TypeError, "% only accepts numbers")
 	}
 
 	num2, ok := args.Cdr.(*cons.Cons).Car.(*numbers.Number)
 	if !ok {
-		return nil, errors.New("% only accepts numbers")
+		return nil, errors.New(errors.TypeError, "% only accepts numbers")
 	}
 
 	return num1.Modulo(num2)
@@ -174,7 +174,7 @@ func NumberMax(args *cons.Cons, env environment.Environment, context interface{}
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New("MAX only accepts numbers")
+			return false, errors.New(errors.TypeError, "MAX only accepts numbers")
 		}
 
 		if max == nil {
@@ -204,7 +204,7 @@ func NumberMin(args *cons.Cons, env environment.Environment, context interface{}
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New("MIN only accepts numbers")
+			return false, errors.New(errors.TypeError, "MIN only accepts numbers")
 		}
 
 		if min == nil {
@@ -236,7 +236,7 @@ func NumberGreaterThan(args *cons.Cons, env environment.Environment, context int
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New("> only accepts numbers")
+			return false, errors.New(errors.TypeError, "> only accepts numbers")
 		}
 
 		if prev != nil {
@@ -276,7 +276,7 @@ func NumberGreaterThanOrEqual(args *cons.Cons, env environment.Environment, cont
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New(">= only accepts numbers")
+			return false, errors.New(errors.TypeError, ">= only accepts numbers")
 		}
 
 		if prev != nil {
@@ -316,7 +316,7 @@ func NumberLesserThan(args *cons.Cons, env environment.Environment, context inte
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New("< only accepts numbers")
+			return false, errors.New(errors.TypeError, "< only accepts numbers")
 		}
 
 		if prev != nil {
@@ -356,7 +356,7 @@ func NumberLesserThanOrEqual(args *cons.Cons, env environment.Environment, conte
 	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
 		num, ok := obj.(*numbers.Number)
 		if !ok {
-			return false, errors.New("<= only accepts numbers")
+			return false, errors.New(errors.TypeError, "<= only accepts numbers")
 		}
 
 		if prev != nil {
@@ -388,55 +388,55 @@ func NumberLesserThanOrEqual(args *cons.Cons, env environment.Environment, conte
 
 // CreateBuiltinNumberGreaterThan creates a function object
 func CreateBuiltinNumberGreaterThan() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberGreaterThan, 1, true)
+	return functions.NewBuiltinFunction(NumberGreaterThan, 1, function.Variadic, true)
 }
 
 // CreateBuiltinNumberGreaterThanOrEqual creates a function object
 func CreateBuiltinNumberGreaterThanOrEqual() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberGreaterThanOrEqual, 1, true)
+	return functions.NewBuiltinFunction(NumberGreaterThanOrEqual, 1, function.Variadic, true)
 }
 
 // CreateBuiltinNumberLesserThan creates a function object
 func CreateBuiltinNumberLesserThan() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberLesserThan, 1, true)
+	return functions.NewBuiltinFunction(NumberLesserThan, 1, function.Variadic, true)
 }
 
 // CreateBuiltinNumberLesserThanOrEqual creates a function object
 func CreateBuiltinNumberLesserThanOrEqual() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberLesserThanOrEqual, 1, true)
+	return functions.NewBuiltinFunction(NumberLesserThanOrEqual, 1, function.Variadic, true)
 }
 
 // CreateBuiltinNumberAdd creates a function object
 func CreateBuiltinNumberAdd() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberAdd, 1, true)
+	return functions.NewBuiltinFunction(NumberAdd, 1, function.Variadic, true)
 }
 
 // CreateBuiltinNumberSubtract creates a function object
 func CreateBuiltinNumberSubtract() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberSubtract, 1, true)
+	return functions.NewBuiltinFunction(NumberSubtract, 1, function.Variadic, true)
 }
 
 // CreateBuiltinNumberMultiply creates a function object
 func CreateBuiltinNumberMultiply() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberMultiply, 1, true)
+	return functions.NewBuiltinFunction(NumberMultiply, 1, function.Variadic, true)
 }
 
 // CreateBuiltinNumberDivide creates a function object
 func CreateBuiltinNumberDivide() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberDivide, 1, true)
+	return functions.NewBuiltinFunction(NumberDivide, 1, function.Variadic, true)
 }
 
 // CreateBuiltinNumberModulo creates a function object
 func CreateBuiltinNumberModulo() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberModulo, 2, true)
+	return functions.NewBuiltinFunction(NumberModulo, 2, 2, true)
 }
 
 // CreateBuiltinNumberMax creates a function object
 func CreateBuiltinNumberMax() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberMax, 1, true)
+	return functions.NewBuiltinFunction(NumberMax, 1, function.Variadic, true)
 }
 
 // CreateBuiltinNumberMin creates a function object
 func CreateBuiltinNumberMin() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(NumberMin, 1, true)
+	return functions.NewBuiltinFunction(NumberMin, 1, function.Variadic, true)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
 	"github.com/almerlucke/glisp/types/functions"
@@ -23,5 +24,5 @@ func Print(args *cons.Cons, env environment.Environment, context interface{}) (t
 
 // CreateBuiltinPrint creates a builtin function object
 func CreateBuiltinPrint() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Print, 0, true)
+	return functions.NewBuiltinFunction(Print, 0, function.Variadic, true)
 }
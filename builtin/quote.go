@@ -7,12 +7,19 @@ import (
 	"github.com/almerlucke/glisp/types/functions"
 )
 
-// Quote builtin function
-func Quote(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	return args.Car, nil
+// CompileQuote compiles a quote special form
+func CompileQuote(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	return constant(args.Car), nil
+}
+
+// constant returns a closure that always returns obj
+func constant(obj types.Object) environment.Closure {
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		return obj, nil
+	}
 }
 
 // CreateBuiltinQuote creates a builtin function object
-func CreateBuiltinQuote() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Quote, 1, false)
+func CreateBuiltinQuote() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileQuote, 1, 1)
 }
@@ -1,27 +1,26 @@
 package builtin
 
 import (
-	"errors"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
 // Return builtin function
 func Return(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
 	if !env.HasDepthContext("CallDepth") {
-		return nil, errors.New("RETURN can only be used inside a macro or lambda body")
+		return nil, errors.New(errors.GeneralError, "RETURN can only be used inside a macro or lambda body")
 	}
 
-	returnContext := function.ReturnContext{Object: args.Car}
+	function.ExitTo(function.ReturnPoint, args.Car)
 
-	panic(&returnContext)
+	return nil, nil
 }
 
 // CreateBuiltinReturn creates a builtin function object
 func CreateBuiltinReturn() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Return, 1, true)
+	return functions.NewBuiltinFunction(Return, 1, 1, true)
 }
@@ -2,35 +2,33 @@ package builtin
 
 import (
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/scope"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
 	"github.com/almerlucke/glisp/types/functions"
 )
 
-// Scope buildin function
-func Scope(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	// Push a new scope
-	env.PushScope(nil)
+// CompileScope compiles a scope special form
+func CompileScope(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	scopeCompiler := c.NewScope()
+	layout := scopeCompiler.Layout()
+	body := scopeCompiler.CompileBody(args)
 
-	// Make sure we pop the scope after completion
-	defer env.PopScope()
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		// Push a new scope
+		env.PushScope(scope.New(layout, env.CurrentScope()))
 
-	var val types.Object = types.NIL
-	var err error
+		// Make sure we pop the scope after completion
+		defer env.PopScope()
 
-	err = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
-		val, err = env.Eval(obj, context)
-		return false, err
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	return val, nil
+		// Last form is in tail position, arguments of a tail call are
+		// evaluated before the scope is popped
+		return body(env, context)
+	}, nil
 }
 
 // CreateBuiltinScope creates a builtin function object
-func CreateBuiltinScope() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Scope, 0, false)
+func CreateBuiltinScope() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileScope, 0, function.Variadic)
 }
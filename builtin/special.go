@@ -0,0 +1,163 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// declareSpecial checks the symbol of DEFVAR or DEFPARAMETER and declares it
+// special, this is done at compile time so the forms compiled after the
+// declaration refer to the dynamic binding
+func declareSpecial(name string, obj types.Object) (*symbols.Symbol, error) {
+	sym, ok := obj.(*symbols.Symbol)
+	if !ok || sym.IsKeyword {
+		return nil, errors.Errorf(errors.TypeError, "%v expected a symbol as first argument", name)
+	}
+
+	if sym.Reserved {
+		return nil, errors.Errorf(errors.GeneralError, "can't assign to a reserved symbol %v", sym)
+	}
+
+	sym.Special = true
+
+	return sym, nil
+}
+
+// CompileDefvar compiles a defvar special form, the value is only evaluated
+// and assigned if the special variable is unbound
+func CompileDefvar(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	sym, err := declareSpecial("DEFVAR", args.Car)
+	if err != nil {
+		return nil, err
+	}
+
+	var value environment.Closure
+	if args.Cdr.Type() == types.Cons {
+		value = c.Compile(args.Cdr.(*cons.Cons).Car)
+	}
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		if value == nil || env.GetGlobalBinding(sym) != nil {
+			return sym, nil
+		}
+
+		val, err := value(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		env.AddGlobalBinding(sym, val)
+
+		return sym, nil
+	}, nil
+}
+
+// CompileDefparameter compiles a defparameter special form, the value is
+// always assigned
+func CompileDefparameter(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	sym, err := declareSpecial("DEFPARAMETER", args.Car)
+	if err != nil {
+		return nil, err
+	}
+
+	value := c.Compile(args.Cdr.(*cons.Cons).Car)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		val, err := value(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		env.AddGlobalBinding(sym, val)
+
+		return sym, nil
+	}, nil
+}
+
+// bindSpecials rebinds special variables and returns a function which
+// restores the previous bindings
+func bindSpecials(syms []*symbols.Symbol, values []types.Object, env environment.Environment) func() {
+	previous := make([]types.Object, len(syms))
+
+	for i, sym := range syms {
+		previous[i] = env.GetGlobalBinding(sym)
+		env.AddGlobalBinding(sym, values[i])
+	}
+
+	return func() {
+		// Restore in reverse order so a variable bound twice gets its
+		// oldest binding back
+		for i := len(syms) - 1; i >= 0; i-- {
+			env.AddGlobalBinding(syms[i], previous[i])
+		}
+	}
+}
+
+// CompileDynamicLet compiles a dynamic-let special form, the special
+// variables are rebound for the dynamic extent of the body so functions
+// called from the body see the new bindings. The previous bindings are
+// restored however the body is exited
+func CompileDynamicLet(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	bindingsType := args.Car.Type()
+	if bindingsType != types.Cons && bindingsType != types.Null {
+		return nil, errors.New(errors.TypeError, "DYNAMIC-LET expected a binding list as first argument")
+	}
+
+	syms := []*symbols.Symbol{}
+	values := []environment.Closure{}
+
+	for bindings := args.Car; bindings.Type() == types.Cons; bindings = bindings.(*cons.Cons).Cdr {
+		binding, ok := bindings.(*cons.Cons).Car.(*cons.Cons)
+		if !ok || binding.Cdr.Type() != types.Cons {
+			return nil, errors.New(errors.GeneralError, "DYNAMIC-LET expected bindings of the form (symbol value)")
+		}
+
+		sym, ok := binding.Car.(*symbols.Symbol)
+		if !ok || !sym.Special {
+			return nil, errors.Errorf(errors.GeneralError, "DYNAMIC-LET can only bind special variables, got %v", binding.Car)
+		}
+
+		syms = append(syms, sym)
+		values = append(values, c.Compile(binding.Cdr.(*cons.Cons).Car))
+	}
+
+	body := CompileProgn(args.Cdr, c)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		// Values are evaluated before any variable is rebound
+		vals := make([]types.Object, len(values))
+
+		for i, value := range values {
+			val, err := value(env, context)
+			if err != nil {
+				return nil, err
+			}
+
+			vals[i] = val
+		}
+
+		defer bindSpecials(syms, vals, env)()
+
+		return body(env, context)
+	}, nil
+}
+
+// CreateBuiltinDefvar creates a builtin function object
+func CreateBuiltinDefvar() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDefvar, 1, 2)
+}
+
+// CreateBuiltinDefparameter creates a builtin function object
+func CreateBuiltinDefparameter() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDefparameter, 2, 2)
+}
+
+// CreateBuiltinDynamicLet creates a builtin function object
+func CreateBuiltinDynamicLet() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileDynamicLet, 1, function.Variadic)
+}
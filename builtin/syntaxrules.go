@@ -0,0 +1,536 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+
+	globals "github.com/almerlucke/glisp/globals/symbols"
+)
+
+// bindings maps the pattern variables of a syntax rule to the matched forms,
+// a variable followed by an ellipsis is bound to a sequence holding the
+// bindings of each repetition
+type bindings map[*symbols.Symbol]interface{}
+
+// sequence of bindings matched by a pattern followed by an ellipsis
+type sequence []bindings
+
+// syntaxRule is a pattern and the template it is rewritten to
+type syntaxRule struct {
+	pattern  types.Object
+	template types.Object
+}
+
+// syntaxRules holds the rules of a hygienic macro
+type syntaxRules struct {
+	literals []*symbols.Symbol
+	rules    []*syntaxRule
+}
+
+// isEllipsis checks if obj is the ellipsis symbol ...
+func isEllipsis(obj types.Object) bool {
+	sym, ok := obj.(*symbols.Symbol)
+	return ok && sym.Name == "..."
+}
+
+// isWildcard checks if obj is the wildcard symbol _
+func isWildcard(obj types.Object) bool {
+	sym, ok := obj.(*symbols.Symbol)
+	return ok && sym.Name == "_"
+}
+
+// isForm checks if obj is the reserved symbol naming one of the builtin forms
+// in names
+func isForm(obj types.Object, names ...string) bool {
+	sym, ok := obj.(*symbols.Symbol)
+	if !ok || !sym.Reserved {
+		return false
+	}
+
+	for _, name := range names {
+		if sym.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isLiteral checks if sym is one of the literals of the rules
+func (sr *syntaxRules) isLiteral(sym *symbols.Symbol) bool {
+	for _, literal := range sr.literals {
+		if literal == sym {
+			return true
+		}
+	}
+
+	return false
+}
+
+// patternVariables collects the pattern variables of a pattern
+func (sr *syntaxRules) patternVariables(pattern types.Object, vars []*symbols.Symbol) []*symbols.Symbol {
+	switch pattern.Type() {
+	case types.Symbol:
+		sym := pattern.(*symbols.Symbol)
+		if !isEllipsis(sym) && !isWildcard(sym) && !sr.isLiteral(sym) {
+			vars = append(vars, sym)
+		}
+	case types.Cons:
+		vars = sr.patternVariables(pattern.(*cons.Cons).Car, vars)
+		vars = sr.patternVariables(pattern.(*cons.Cons).Cdr, vars)
+	}
+
+	return vars
+}
+
+// checkPattern checks that an ellipsis only follows the last subpattern of
+// a list
+func checkPattern(pattern types.Object) error {
+	for ; pattern.Type() == types.Cons; pattern = pattern.(*cons.Cons).Cdr {
+		pair := pattern.(*cons.Cons)
+
+		if isEllipsis(pair.Car) {
+			return errors.New(errors.GeneralError, "SYNTAX-RULES pattern can't start with an ellipsis")
+		}
+
+		if pair.Cdr.Type() == types.Cons && isEllipsis(pair.Cdr.(*cons.Cons).Car) && pair.Cdr.(*cons.Cons).Cdr != types.NIL {
+			return errors.New(errors.GeneralError, "SYNTAX-RULES ellipsis must be the last element of a pattern list")
+		}
+
+		err := checkPattern(pair.Car)
+		if err != nil {
+			return err
+		}
+
+		if pair.Cdr.Type() == types.Cons && isEllipsis(pair.Cdr.(*cons.Cons).Car) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// match matches form against pattern and adds the pattern variables to b
+func (sr *syntaxRules) match(pattern types.Object, form types.Object, b bindings) bool {
+	switch pattern.Type() {
+	case types.Symbol:
+		sym := pattern.(*symbols.Symbol)
+
+		if isWildcard(sym) {
+			return true
+		}
+
+		if sr.isLiteral(sym) {
+			// A literal also matches a symbol renamed from the literal
+			renamed, ok := form.(*symbols.Symbol)
+			for ; ok && renamed != nil; renamed = renamed.Alias {
+				if renamed == sym {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		b[sym] = form
+
+		return true
+	case types.Cons:
+		pair := pattern.(*cons.Cons)
+
+		if pair.Cdr.Type() == types.Cons && isEllipsis(pair.Cdr.(*cons.Cons).Car) {
+			// Match the rest of the form against the subpattern
+			seq := sequence{}
+
+			for ; form.Type() == types.Cons; form = form.(*cons.Cons).Cdr {
+				repetition := bindings{}
+				if !sr.match(pair.Car, form.(*cons.Cons).Car, repetition) {
+					return false
+				}
+
+				seq = append(seq, repetition)
+			}
+
+			if form != types.NIL {
+				return false
+			}
+
+			for _, sym := range sr.patternVariables(pair.Car, nil) {
+				b[sym] = seq
+			}
+
+			return true
+		}
+
+		if form.Type() != types.Cons {
+			return false
+		}
+
+		return sr.match(pair.Car, form.(*cons.Cons).Car, b) && sr.match(pair.Cdr, form.(*cons.Cons).Cdr, b)
+	}
+
+	return pattern.Equal(form)
+}
+
+// quoting tells which parts of a template are literal data, symbols in
+// literal data are not renamed. Quoted forms, backquoted forms outside of
+// unquotes and the keys of CASE, ECASE and TYPECASE clauses are literal data
+type quoting struct {
+	quoted bool
+	depth  int
+	clause bool
+}
+
+// caseForms are the forms with clauses starting with literal keys
+var caseForms = []string{"CASE", "ECASE", "TYPECASE"}
+
+// literal checks if a template part is literal data
+func (q quoting) literal() bool {
+	return q.quoted || q.depth > 0
+}
+
+// enter returns the quoting of the elements of list
+func (q quoting) enter(list *cons.Cons) quoting {
+	switch {
+	case q.quoted:
+	case list.Car == globals.QuoteSymbol && q.depth == 0:
+		q.quoted = true
+	case list.Car == globals.BackquoteSymbol:
+		q.depth++
+	case list.Car == globals.UnquoteSymbol || list.Car == globals.SpliceSymbol:
+		if q.depth > 0 {
+			q.depth--
+		}
+	}
+
+	return q
+}
+
+// element returns the quoting of the element at index of list, q is the
+// quoting returned by enter for list
+func (q quoting) element(list *cons.Cons, index int) quoting {
+	if q.clause {
+		return quoting{quoted: index == 0}
+	}
+
+	if !q.literal() && index >= 2 && isForm(list.Car, caseForms...) {
+		return quoting{clause: true}
+	}
+
+	return quoting{quoted: q.quoted, depth: q.depth}
+}
+
+// renamable checks if a symbol introduced by a template is renamed, reserved
+// symbols, keywords and special variables are never renamed
+func renamable(sym *symbols.Symbol) bool {
+	return !sym.Reserved && !sym.IsKeyword && !sym.Special
+}
+
+// expander rewrites a template, symbols introduced by the template are
+// renamed so bindings made by the expansion can't capture or shadow the
+// variables of the macro call. Every expansion gets its own renames
+type expander struct {
+	renames map[*symbols.Symbol]*symbols.Symbol
+}
+
+// rename returns the renamed symbol for a template symbol
+func (e *expander) rename(sym *symbols.Symbol) *symbols.Symbol {
+	if !renamable(sym) {
+		return sym
+	}
+
+	renamed, ok := e.renames[sym]
+	if !ok {
+		renamed = &symbols.Symbol{
+			Name:  sym.Name,
+			Alias: sym,
+		}
+
+		e.renames[sym] = renamed
+	}
+
+	return renamed
+}
+
+// expand rewrites template with the bindings in b, literal data in the
+// template is not renamed
+func (e *expander) expand(template types.Object, b bindings, q quoting) (types.Object, error) {
+	switch template.Type() {
+	case types.Symbol:
+		sym := template.(*symbols.Symbol)
+
+		bound, ok := b[sym]
+		if !ok {
+			if q.literal() {
+				return sym, nil
+			}
+
+			return e.rename(sym), nil
+		}
+
+		obj, ok := bound.(types.Object)
+		if !ok {
+			return nil, errors.Errorf(errors.GeneralError, "SYNTAX-RULES pattern variable %v must be followed by an ellipsis", sym)
+		}
+
+		return obj, nil
+	case types.Cons:
+		pair := template.(*cons.Cons)
+		q = q.enter(pair)
+
+		builder := cons.ListBuilder{}
+
+		var obj types.Object = pair
+		for index := 0; obj.Type() == types.Cons; index++ {
+			element := obj.(*cons.Cons).Car
+			next := obj.(*cons.Cons).Cdr
+
+			if next.Type() == types.Cons && isEllipsis(next.(*cons.Cons).Car) {
+				err := e.expandRepetitions(element, b, q.element(pair, index), &builder)
+				if err != nil {
+					return nil, err
+				}
+
+				// Skip the ellipsis
+				obj = next.(*cons.Cons).Cdr
+
+				continue
+			}
+
+			expanded, err := e.expand(element, b, q.element(pair, index))
+			if err != nil {
+				return nil, err
+			}
+
+			builder.PushBackObject(expanded)
+
+			obj = next
+		}
+
+		if builder.Head == nil {
+			return obj, nil
+		}
+
+		builder.Tail.Cdr = obj
+
+		return builder.Head, nil
+	}
+
+	return template, nil
+}
+
+// expandRepetitions expands a template followed by an ellipsis once for
+// every repetition of the sequences bound to its pattern variables
+func (e *expander) expandRepetitions(template types.Object, b bindings, q quoting, builder *cons.ListBuilder) error {
+	var seqs []sequence
+	var syms []*symbols.Symbol
+
+	collectSequences(template, b, &syms, &seqs)
+
+	if len(seqs) == 0 {
+		return errors.Errorf(errors.GeneralError, "SYNTAX-RULES template %v followed by an ellipsis contains no pattern variable followed by an ellipsis", template)
+	}
+
+	n := len(seqs[0])
+	for _, seq := range seqs[1:] {
+		if len(seq) != n {
+			return errors.Errorf(errors.GeneralError, "SYNTAX-RULES pattern variables of template %v matched a different number of forms", template)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		repetition := bindings{}
+		for sym, bound := range b {
+			repetition[sym] = bound
+		}
+
+		for j, sym := range syms {
+			repetition[sym] = seqs[j][i][sym]
+		}
+
+		expanded, err := e.expand(template, repetition, q)
+		if err != nil {
+			return err
+		}
+
+		builder.PushBackObject(expanded)
+	}
+
+	return nil
+}
+
+// collectSequences collects the pattern variables in template bound to a
+// sequence
+func collectSequences(template types.Object, b bindings, syms *[]*symbols.Symbol, seqs *[]sequence) {
+	switch template.Type() {
+	case types.Symbol:
+		sym := template.(*symbols.Symbol)
+
+		seq, ok := b[sym].(sequence)
+		if !ok {
+			return
+		}
+
+		for _, collected := range *syms {
+			if collected == sym {
+				return
+			}
+		}
+
+		*syms = append(*syms, sym)
+		*seqs = append(*seqs, seq)
+	case types.Cons:
+		collectSequences(template.(*cons.Cons).Car, b, syms, seqs)
+		collectSequences(template.(*cons.Cons).Cdr, b, syms, seqs)
+	}
+}
+
+// checkTemplate checks that the symbols introduced by a template don't refer
+// to local variables of the definition site, renamed symbols only fall back
+// to global bindings because expansions are compiled at the call site
+func checkTemplate(template types.Object, vars []*symbols.Symbol, q quoting, c environment.Compiler) error {
+	switch template.Type() {
+	case types.Symbol:
+		sym := template.(*symbols.Symbol)
+
+		if q.literal() || !renamable(sym) || isEllipsis(sym) {
+			return nil
+		}
+
+		for _, v := range vars {
+			if v == sym {
+				return nil
+			}
+		}
+
+		if _, _, ok := c.Layout().Resolve(sym); ok {
+			return errors.Errorf(errors.GeneralError, "SYNTAX-RULES template refers to local variable %v, templates can only refer to global variables or pass local variables as macro arguments", sym)
+		}
+	case types.Cons:
+		pair := template.(*cons.Cons)
+		q = q.enter(pair)
+
+		var obj types.Object = pair
+		for index := 0; obj.Type() == types.Cons; index++ {
+			err := checkTemplate(obj.(*cons.Cons).Car, vars, q.element(pair, index), c)
+			if err != nil {
+				return err
+			}
+
+			obj = obj.(*cons.Cons).Cdr
+		}
+	}
+
+	return nil
+}
+
+// expand rewrites a macro call form with the first rule whose pattern
+// matches the form, the first element of the pattern is ignored
+func (sr *syntaxRules) expand(form *cons.Cons) (types.Object, error) {
+	for _, rule := range sr.rules {
+		b := bindings{}
+
+		if sr.match(rule.pattern, form.Cdr, b) {
+			e := &expander{
+				renames: map[*symbols.Symbol]*symbols.Symbol{},
+			}
+
+			return e.expand(rule.template, b, quoting{})
+		}
+	}
+
+	return nil, errors.Errorf(errors.ArityError, "no syntax rule matches %v", form)
+}
+
+// CompileSyntaxRules compiles a syntax-rules special form, it evaluates to a
+// hygienic macro rewriting the call form with the first matching rule. A rule
+// has the form (pattern template), the first element of a pattern stands for
+// the macro name and is ignored. Symbols in the pattern are pattern variables
+// except for the literals, _ and the ellipsis ... which matches zero or more
+// forms against the subpattern before it. Symbols introduced by a template are
+// renamed on every expansion, unbound renamed symbols refer to the global
+// binding of the original symbol. Quoted and backquoted data and CASE keys
+// in a template are not renamed, a template can't refer to the local
+// variables of the definition site
+func CompileSyntaxRules(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	literalsType := args.Car.Type()
+	if literalsType != types.Cons && literalsType != types.Null {
+		return nil, errors.New(errors.TypeError, "SYNTAX-RULES expected a literal list as first argument")
+	}
+
+	sr := &syntaxRules{}
+
+	for obj := args.Car; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		sym, ok := obj.(*cons.Cons).Car.(*symbols.Symbol)
+		if !ok {
+			return nil, errors.New(errors.TypeError, "SYNTAX-RULES literal list must contain only symbols")
+		}
+
+		sr.literals = append(sr.literals, sym)
+	}
+
+	for obj := args.Cdr; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		rule, ok := obj.(*cons.Cons).Car.(*cons.Cons)
+		if !ok || rule.Car.Type() != types.Cons || rule.Cdr.Type() != types.Cons || rule.Cdr.(*cons.Cons).Cdr != types.NIL {
+			return nil, errors.New(errors.GeneralError, "SYNTAX-RULES expected rules of the form (pattern template)")
+		}
+
+		err := checkPattern(rule.Car)
+		if err != nil {
+			return nil, err
+		}
+
+		r := &syntaxRule{
+			pattern:  rule.Car.(*cons.Cons).Cdr,
+			template: rule.Cdr.(*cons.Cons).Car,
+		}
+
+		err = checkTemplate(r.template, sr.patternVariables(r.pattern, nil), quoting{}, c)
+		if err != nil {
+			return nil, err
+		}
+
+		sr.rules = append(sr.rules, r)
+	}
+
+	// The macro binds the call form to &WHOLE and expands it with the rules
+	wholeSym := &symbols.Symbol{Name: "FORM"}
+	restSym := &symbols.Symbol{Name: "ARGS"}
+
+	bodyCompiler := c.NewScope()
+
+	lambdaList, err := compileDestructuringLambdaList("SYNTAX-RULES", cons.ListFromSlice([]types.Object{
+		globals.AndWholeSymbol, wholeSym, globals.AndRestSymbol, restSym,
+	}), bodyCompiler, true)
+	if err != nil {
+		return nil, err
+	}
+
+	whole := bodyCompiler.Resolve(wholeSym)
+	layout := bodyCompiler.Layout()
+
+	body := func(env environment.Environment, context interface{}) (types.Object, error) {
+		form, err := whole.Get(env)
+		if err != nil {
+			return nil, err
+		}
+
+		return sr.expand(form.(*cons.Cons))
+	}
+
+	pos := c.Position()
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		return functions.NewMacroFunction(lambdaList, nil, layout, env.CaptureScope(), body, pos), nil
+	}, nil
+}
+
+// CreateBuiltinSyntaxRules creates a builtin function object
+func CreateBuiltinSyntaxRules() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileSyntaxRules, 1, function.Variadic)
+}
@@ -0,0 +1,179 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+const (
+	tagbodyExit = "TAGBODY"
+)
+
+// tagbody holds the compiled statements of a tagbody, targets holds for
+// each tag the index of the statement following the tag and refs the
+// variable of its label
+type tagbody struct {
+	tags       []types.Object
+	targets    []int
+	refs       []environment.Reference
+	statements []environment.Closure
+	layout     *scope.Layout
+}
+
+// run evaluates the statements from start, it returns the index of the
+// statement to continue with when GO exits to one of the points
+func (tb *tagbody) run(start int, points []*function.ExitPoint, env environment.Environment, context interface{}) (next int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			exit, ok := r.(*function.Exit)
+			if ok {
+				for i, point := range points {
+					if exit.Point == point {
+						next, err = tb.targets[i], nil
+						return
+					}
+				}
+			}
+
+			// Continue to panic
+			panic(r)
+		}
+	}()
+
+	for i := start; i < len(tb.statements); i++ {
+		_, err = tb.statements[i](env, context)
+		if err != nil {
+			return -1, err
+		}
+	}
+
+	return -1, nil
+}
+
+// isTag checks if obj in the body of a tagbody is a tag
+func isTag(obj types.Object) bool {
+	return obj.Type() == types.Symbol || obj.Type() == types.Number
+}
+
+// CompileTagbody compiles a tagbody special form, symbols and numbers in the
+// body are tags, other forms are statements. GO continues the evaluation
+// after the tag in the innermost lexically enclosing tagbody with that tag
+func CompileTagbody(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	tb := &tagbody{}
+
+	var body types.Object = types.NIL
+	if args != nil {
+		body = args
+	}
+
+	bodyCompiler := c.NewScope()
+	tb.layout = bodyCompiler.Layout()
+
+	// Define all tags first so GO can jump forward
+	statements := 0
+
+	for obj := body; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		element := obj.(*cons.Cons).Car
+
+		if !isTag(element) {
+			statements++
+			continue
+		}
+
+		for _, tag := range tb.tags {
+			if tag.Eql(element) {
+				return nil, errors.Errorf(errors.GeneralError, "TAGBODY contains duplicate tag %v", element)
+			}
+		}
+
+		tb.tags = append(tb.tags, element)
+		tb.targets = append(tb.targets, statements)
+		tb.refs = append(tb.refs, bodyCompiler.Resolve(tb.layout.DefineLabel(tagbodyExit, element)))
+	}
+
+	for obj := body; obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		element := obj.(*cons.Cons).Car
+
+		if !isTag(element) {
+			tb.statements = append(tb.statements, bodyCompiler.Compile(element))
+		}
+	}
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		// Push a new scope for the labels of the tags
+		env.PushScope(scope.New(tb.layout, env.CurrentScope()))
+
+		// Make sure we pop the scope after completion
+		defer env.PopScope()
+
+		// The exit points are identified by new objects for every evaluation
+		points := make([]*function.ExitPoint, len(tb.tags))
+		for i := range tb.tags {
+			tag := &symbols.Symbol{Name: tagbodyExit}
+			tb.refs[i].Bind(env, tag)
+
+			points[i] = &function.ExitPoint{Kind: tagbodyExit, Tag: tag}
+		}
+
+		defer setExitPoints(env, pushExitPoints(env, points...))
+
+		next := 0
+
+		for next >= 0 {
+			var err error
+
+			next, err = tb.run(next, points, env, context)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return types.NIL, nil
+	}, nil
+}
+
+// CompileGo compiles a go special form, the tag is not evaluated
+func CompileGo(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	tag := args.Car
+	if !isTag(tag) {
+		return nil, errors.New(errors.TypeError, "GO expected a symbol or number as tag")
+	}
+
+	label, ok := c.Layout().ResolveLabel(tagbodyExit, tag)
+	if !ok {
+		return nil, errors.Errorf(errors.GeneralError, "GO no tagbody with tag %v", tag)
+	}
+
+	ref := c.Resolve(label)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		point, err := labelExitPoint(tagbodyExit, ref, env)
+		if err != nil {
+			return nil, err
+		}
+
+		if point == nil {
+			return nil, errors.Errorf(errors.GeneralError, "GO tagbody with tag %v is no longer active", tag)
+		}
+
+		function.ExitTo(point, types.NIL)
+
+		return nil, nil
+	}, nil
+}
+
+// CreateBuiltinTagbody creates a builtin function object
+func CreateBuiltinTagbody() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileTagbody, 0, function.Variadic)
+}
+
+// CreateBuiltinGo creates a builtin function object
+func CreateBuiltinGo() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileGo, 1, 1)
+}
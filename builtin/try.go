@@ -1,83 +1,212 @@
 package builtin
 
 import (
-	"errors"
+	goErrors "errors"
 
 	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 	"github.com/almerlucke/glisp/types/strings"
+	"github.com/almerlucke/glisp/types/symbols"
 )
 
-type tryContext struct {
-	Err string
+// catchClause selects a caught object by the keyword returned by TYPE-OF
+// or by a predicate function
+type catchClause struct {
+	typeSym   *symbols.Symbol
+	predicate environment.Closure
+	handler   environment.Closure
 }
 
-// Try builtin function
-func Try(args *cons.Cons, env environment.Environment, context interface{}) (result types.Object, err error) {
-	tryPart := args.Car
-	catchPart := args.Cdr.(*cons.Cons).Car
-	var alwaysPart types.Object = types.NIL
+// matches checks if the clause handles obj
+func (clause *catchClause) matches(obj types.Object, env environment.Environment, context interface{}) (bool, error) {
+	if clause.typeSym != nil {
+		return typeOf(obj, env) == clause.typeSym, nil
+	}
 
-	if args.Cdr.(*cons.Cons).Cdr.Type() == types.Cons {
-		alwaysPart = args.Cdr.(*cons.Cons).Cdr.(*cons.Cons).Car
+	predicate, err := clause.predicate(env, context)
+	if err != nil {
+		return false, err
 	}
 
-	env.PushDepthContext("TryDepth")
+	fun, ok := predicate.(function.Function)
+	if !ok {
+		return false, errors.Errorf(errors.TypeError, "TRY expected a predicate function, got %v", predicate)
+	}
 
-	defer func() {
-		env.PopDepthContext("TryDepth")
+	result, err := functions.Apply(fun, cons.ListFromSlice([]types.Object{obj}), env, context)
+	if err != nil {
+		return false, err
+	}
 
-		if r := recover(); r != nil {
-			tctx, ok := r.(*tryContext)
-			if ok {
-				result, err = env.Eval(catchPart, context)
-				if err == nil {
-					lb := cons.ListBuilder{}
-					lb.PushBackObject(catchPart)
-					lb.PushBackObject(strings.String(tctx.Err))
-					result, err = env.Eval(lb.Head, context)
+	return result != types.NIL, nil
+}
 
-					// Evaluate always part
-					env.Eval(alwaysPart, context)
-				}
-			} else {
-				// Continue to panic
-				panic(r)
-			}
+// ApplyHandler calls a handler function with the handled object, a handler
+// with an empty lambda list like (lambda () ...) is called without arguments
+func ApplyHandler(fun function.Function, obj types.Object, env environment.Environment, context interface{}) (types.Object, error) {
+	if fun.MaxArgs() == 0 {
+		return functions.Apply(fun, nil, env, context)
+	}
+
+	return functions.Apply(fun, cons.ListFromSlice([]types.Object{obj}), env, context)
+}
+
+// caught returns the object passed to the catch handler if err can be
+// caught by TRY, thrown objects are passed as is and all other errors are
+// passed as condition holding the kind, message, position and stack of
+// the error. Cancellation of the evaluation can't be caught
+func caught(err error) (types.Object, bool) {
+	if goErrors.Is(err, errors.ErrCancelled) {
+		return nil, false
+	}
+
+	_, e := errors.Find(err)
+	if e.Kind == errors.UserError {
+		return e.Object, true
+	}
+
+	return e, true
+}
+
+// compileCatchClauses compiles a list of catch clauses of the form
+// (selector (arg) body...), the selector is a keyword or a predicate
+func compileCatchClauses(clauses *cons.Cons, c environment.Compiler) ([]*catchClause, error) {
+	compiled := []*catchClause{}
+
+	for obj := types.Object(clauses); obj.Type() == types.Cons; obj = obj.(*cons.Cons).Cdr {
+		clause, ok := obj.(*cons.Cons).Car.(*cons.Cons)
+		if !ok || clause.Cdr.Type() != types.Cons {
+			return nil, errors.New(errors.GeneralError, "TRY expected catch clauses of the form (selector (arg) body...)")
+		}
+
+		handler, err := CompileLambda(clause.Cdr.(*cons.Cons), c)
+		if err != nil {
+			return nil, err
+		}
+
+		catch := &catchClause{
+			handler: handler,
+		}
+
+		if sym, ok := clause.Car.(*symbols.Symbol); ok && sym.IsKeyword {
+			catch.typeSym = sym
 		} else {
-			// Evaluate always part
-			env.Eval(alwaysPart, context)
+			catch.predicate = c.Compile(clause.Car)
 		}
-	}()
 
-	return env.Eval(tryPart, context)
+		compiled = append(compiled, catch)
+	}
+
+	return compiled, nil
 }
 
-// Throw an exception
-func Throw(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
-	if !env.HasDepthContext("TryDepth") {
-		return nil, errors.New("THROW can only be used inside a try block")
+// CompileTry compiles a try special form, the catch part is either a
+// function called with the caught object or a list of catch clauses, the
+// first matching clause handles the object. Objects not handled by any
+// clause are thrown again
+func CompileTry(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	var catchPart environment.Closure
+	var catchClauses []*catchClause
+
+	tryPart := c.Compile(args.Car)
+	catchForm := args.Cdr.(*cons.Cons).Car
+
+	if catchForm.Type() == types.Cons && catchForm.(*cons.Cons).Car.Type() == types.Cons {
+		var err error
+
+		catchClauses, err = compileCatchClauses(catchForm.(*cons.Cons), c)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		catchPart = c.Compile(catchForm)
 	}
 
-	if args.Car.Type() != types.String {
-		return nil, errors.New("THROW expected a string as first argument")
+	tryCatch := func(env environment.Environment, context interface{}) (types.Object, error) {
+		result, err := tryPart(env, context)
+		if err == nil {
+			return result, nil
+		}
+
+		obj, ok := caught(err)
+		if !ok {
+			return nil, err
+		}
+
+		var handler types.Object
+
+		if catchPart != nil {
+			handler, err = catchPart(env, context)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			for _, clause := range catchClauses {
+				match, merr := clause.matches(obj, env, context)
+				if merr != nil {
+					return nil, merr
+				}
+
+				if match {
+					handler, err = clause.handler(env, context)
+					if err != nil {
+						return nil, err
+					}
+
+					break
+				}
+			}
+
+			if handler == nil {
+				// Not handled, throw again
+				return nil, err
+			}
+		}
+
+		fun, ok := handler.(function.Function)
+		if !ok {
+			return nil, errors.Errorf(errors.TypeError, "TRY expected a catch function, got %v", handler)
+		}
+
+		return ApplyHandler(fun, obj, env, context)
 	}
 
-	tctx := &tryContext{
-		Err: string(args.Car.(strings.String)),
+	if args.Cdr.(*cons.Cons).Cdr.Type() != types.Cons {
+		return tryCatch, nil
 	}
 
-	panic(tctx)
+	// The always part is evaluated however the try is exited
+	alwaysPart := c.Compile(args.Cdr.(*cons.Cons).Cdr.(*cons.Cons).Car)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		return unwindProtect(tryCatch, alwaysPart, env, context)
+	}, nil
+}
+
+// Throw an object, the object is passed to the catch handler of TRY
+func Throw(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	message := args.Car.String()
+	if str, ok := args.Car.(strings.String); ok {
+		message = string(str)
+	}
+
+	return nil, &errors.Error{
+		Kind:    errors.UserError,
+		Message: message,
+		Object:  args.Car,
+	}
 }
 
 // CreateBuiltinTry creates a builtin function object
-func CreateBuiltinTry() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Try, 2, false)
+func CreateBuiltinTry() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileTry, 2, 3)
 }
 
 // CreateBuiltinThrow creates a builtin function object
 func CreateBuiltinThrow() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Throw, 1, true)
+	return functions.NewBuiltinFunction(Throw, 1, 1, true)
 }
@@ -10,9 +10,14 @@ import (
 
 // TypeOf builtin function
 func TypeOf(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	return typeOf(args.Car, env), nil
+}
+
+// typeOf returns the keyword naming the type of obj
+func typeOf(obj types.Object, env environment.Environment) *symbols.Symbol {
 	var typeSym *symbols.Symbol
 
-	switch args.Car.Type() {
+	switch obj.Type() {
 	case types.Array:
 		typeSym = env.InternKeyword("ARRAY")
 	case types.Null:
@@ -35,12 +40,16 @@ func TypeOf(args *cons.Cons, env environment.Environment, context interface{}) (
 		typeSym = env.InternKeyword("CHARACTER")
 	case types.Symbol:
 		typeSym = env.InternKeyword("SYMBOL")
+	case types.Condition:
+		typeSym = env.InternKeyword("CONDITION")
+	case types.Environment:
+		typeSym = env.InternKeyword("ENVIRONMENT")
 	}
 
-	return typeSym, nil
+	return typeSym
 }
 
 // CreateBuiltinTypeOf creates a builtin function object
 func CreateBuiltinTypeOf() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(TypeOf, 1, true)
+	return functions.NewBuiltinFunction(TypeOf, 1, 1, true)
 }
@@ -14,5 +14,5 @@ func Unquote(args *cons.Cons, env environment.Environment, context interface{})
 
 // CreateBuiltinUnquote creates a builtin function object
 func CreateBuiltinUnquote() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Unquote, 1, true)
+	return functions.NewBuiltinFunction(Unquote, 1, 1, true)
 }
@@ -0,0 +1,78 @@
+package builtin
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/functions"
+)
+
+// runCleanup evaluates the cleanup forms with stepping suspended, so they
+// also run when the evaluation is cancelled or out of steps. Cleanup forms
+// can't be stopped and must end by themselves
+func runCleanup(cleanup environment.Closure, env environment.Environment, context interface{}) error {
+	env.SuspendSteps()
+	defer env.ResumeSteps()
+
+	_, err := cleanup(env, context)
+
+	return err
+}
+
+// unwindProtect evaluates protected and then cleanup, cleanup is also
+// evaluated when protected returns an error or the stack is unwound by a
+// non-local exit. An error of protected, including a cancellation, stays the
+// result and a non-local exit continues after cleanup. An error returned by
+// cleanup is only returned if protected succeeded
+func unwindProtect(protected environment.Closure, cleanup environment.Closure, env environment.Environment, context interface{}) (result types.Object, err error) {
+	unwinding := true
+
+	defer func() {
+		if !unwinding {
+			return
+		}
+
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		// The error of cleanup can't stop the exit
+		runCleanup(cleanup, env, context)
+
+		// Continue to panic
+		panic(r)
+	}()
+
+	result, err = protected(env, context)
+
+	unwinding = false
+
+	cerr := runCleanup(cleanup, env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	return result, nil
+}
+
+// CompileUnwindProtect compiles an unwind-protect special form, the cleanup
+// forms are evaluated however the protected form is exited
+func CompileUnwindProtect(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
+	protected := c.Compile(args.Car)
+	cleanup := CompileProgn(args.Cdr, c)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		return unwindProtect(protected, cleanup, env, context)
+	}, nil
+}
+
+// CreateBuiltinUnwindProtect creates a builtin function object
+func CreateBuiltinUnwindProtect() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileUnwindProtect, 1, function.Variadic)
+}
@@ -1,45 +1,47 @@
 package builtin
 
 import (
-	"errors"
-	"fmt"
-
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/functions"
 	"github.com/almerlucke/glisp/types/symbols"
 )
 
-// Var builtin function
-func Var(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+// CompileVar compiles a var special form
+func CompileVar(args *cons.Cons, c environment.Compiler) (environment.Closure, error) {
 	if args.Car.Type() != types.Symbol {
-		return nil, errors.New("VAR expected a symbol as first argument")
+		return nil, errors.New(errors.TypeError, "VAR expected a symbol as first argument")
 	}
 
 	sym := args.Car.(*symbols.Symbol)
 
 	if sym.Reserved {
-		return nil, fmt.Errorf("can't assign to a reserved symbol %v", sym)
+		return nil, errors.Errorf(errors.GeneralError, "can't assign to a reserved symbol %v", sym)
 	}
 
-	var val types.Object = types.NIL
-	var err error
+	value := c.Compile(types.NIL)
 
 	if args.Cdr.Type() == types.Cons {
-		args = args.Cdr.(*cons.Cons)
-		val, err = env.Eval(args.Car, context)
+		value = c.Compile(args.Cdr.(*cons.Cons).Car)
+	}
+
+	ref := c.Define(sym)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		val, err := value(env, context)
 		if err != nil {
 			return nil, err
 		}
-	}
 
-	env.AddBinding(sym, val)
+		ref.Bind(env, val)
 
-	return val, nil
+		return val, nil
+	}, nil
 }
 
 // CreateBuiltinVar creates a builtin function object
-func CreateBuiltinVar() *functions.BuiltinFunction {
-	return functions.NewBuiltinFunction(Var, 1, false)
+func CreateBuiltinVar() *functions.SpecialForm {
+	return functions.NewSpecialForm(CompileVar, 1, 2)
 }
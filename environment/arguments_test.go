@@ -0,0 +1,33 @@
+package environment_test
+
+import (
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+func TestArgumentErrorsNameFunction(t *testing.T) {
+	tests := []struct {
+		src     string
+		message string
+	}{
+		{"(defun f (a &key b) a) (f)", "F expected at least 1 argument, got 0"},
+		{"(defun f (a &key b) a) (f 1 :c 2)", "F got unknown keyword argument :C"},
+		{"(defun f (a &key b) a) (var g f) (g 1 :c 2)", "F got unknown keyword argument :C"},
+		{"(var g (lambda (a &key b) a)) (g)", "<lambda line 1, column 8> expected at least 1 argument, got 0"},
+		{"(var g (lambda (a &key b) a)) (g 1 :c 2)", "<lambda line 1, column 8> got unknown keyword argument :C"},
+		{"(map '(1 2) gensym)", "GENSYM expected 0 arguments, got 1"},
+	}
+
+	for _, test := range tests {
+		_, err := load(environment.New(), test.src)
+		if err == nil {
+			t.Fatalf("%v: expected an error", test.src)
+		}
+
+		if message := errors.From(err).Message; message != test.message {
+			t.Fatalf("%v: expected %q, got %q", test.src, test.message, message)
+		}
+	}
+}
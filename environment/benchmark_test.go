@@ -0,0 +1,150 @@
+package environment_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/globals/tables"
+	"github.com/almerlucke/glisp/reader"
+	"github.com/almerlucke/glisp/types"
+)
+
+// read reads all objects from source
+func read(env *environment.Environment, source string) []types.Object {
+	rd := reader.New(strings.NewReader(source), tables.DefaultReadTable, tables.DefaultDispatchTable, env)
+
+	objs := []types.Object{}
+
+	for {
+		obj, err := rd.ReadObject()
+		if err != nil {
+			break
+		}
+
+		objs = append(objs, obj)
+	}
+
+	return objs
+}
+
+// eval evaluates objs in env
+func eval(b *testing.B, env *environment.Environment, objs []types.Object) {
+	for _, obj := range objs {
+		_, err := env.Eval(obj, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// silence redirects stdout and stderr so printing examples don't flood the
+// benchmark output, the returned function restores them
+func silence(b *testing.B) func() {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = devNull, devNull
+
+	return func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		devNull.Close()
+	}
+}
+
+// benchmarkExample evaluates the source of an example program in a new
+// environment, creating the environment and reading are not timed
+func benchmarkExample(b *testing.B, name string) {
+	source, err := ioutil.ReadFile("../examples/" + name + "/source.glisp")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	defer silence(b)()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		env := environment.New()
+		objs := read(env, string(source))
+		b.StartTimer()
+
+		eval(b, env, objs)
+	}
+}
+
+// benchmarkProgram evaluates source repeatedly after evaluating setup once
+func benchmarkProgram(b *testing.B, setup string, source string) {
+	env := environment.New()
+
+	eval(b, env, read(env, setup))
+
+	objs := read(env, source)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		eval(b, env, objs)
+	}
+}
+
+func BenchmarkFileExample(b *testing.B) {
+	benchmarkExample(b, "file")
+}
+
+func BenchmarkClosuresExample(b *testing.B) {
+	benchmarkExample(b, "closures")
+}
+
+func BenchmarkConditionsExample(b *testing.B) {
+	benchmarkExample(b, "conditions")
+}
+
+func BenchmarkMacrosExample(b *testing.B) {
+	benchmarkExample(b, "macros")
+}
+
+func BenchmarkVariableAccess(b *testing.B) {
+	benchmarkProgram(b, `
+(var sum (lambda (n)
+  (scope
+    (var a 1) (var b 2) (var c 3) (var i 0)
+    (scope
+      (var d 4)
+      (while (< i n)
+        (do
+          (= i (+ i 1))
+          (+ a b c d)))))))
+`, `(sum 1000)`)
+}
+
+func BenchmarkClosureCreation(b *testing.B) {
+	benchmarkProgram(b, `
+(var make (lambda (n)
+  (scope
+    (var a 1) (var b 2) (var c 3) (var d 4) (var e 5)
+    (var f 6) (var g 7) (var h 8) (var i 0)
+    (while (< i n)
+      (do
+        (= i (+ i 1))
+        (lambda (x) (+ x a)))))))
+`, `(make 1000)`)
+}
+
+func BenchmarkRecursion(b *testing.B) {
+	benchmarkProgram(b, `
+(var fib (lambda (n) (if (< n 2) n (+ (fib (- n 1)) (fib (- n 2))))))
+`, `(fib 15)`)
+}
+
+func BenchmarkMacroLoop(b *testing.B) {
+	benchmarkProgram(b, "(var ++ (macro (arg) `(= ,arg (+ ,arg 1))))", `
+(scope (var a 0) (while (< a 1000) (++ a)))
+`)
+}
@@ -0,0 +1,59 @@
+package environment_test
+
+import (
+	"context"
+	goErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/almerlucke/glisp/environment"
+)
+
+func TestEvalWithContextStopsLoops(t *testing.T) {
+	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	tests := []struct {
+		ctx    context.Context
+		budget uint64
+		src    string
+		cause  error
+	}{
+		{context.Background(), 1000, "(while t 1)", environment.ErrStepBudgetExceeded},
+		{context.Background(), 1000, "(var f (lambda () (f))) (f)", environment.ErrStepBudgetExceeded},
+		{cancelled(), 0, "(while t 1)", context.Canceled},
+		{timeout, 0, "(while t 1)", context.DeadlineExceeded},
+	}
+
+	for _, test := range tests {
+		_, err := loadWithContext(environment.New(), test.ctx, test.budget, test.src)
+
+		var cancelled *environment.CancelledError
+		if !goErrors.As(err, &cancelled) || !goErrors.Is(err, test.cause) {
+			t.Fatalf("%v: expected %v, got %v", test.src, test.cause, err)
+		}
+	}
+}
+
+func TestEvalWithContextWithinBudget(t *testing.T) {
+	env := environment.New()
+
+	result, err := loadWithContext(env, context.Background(), 1000, "(var n 0) (while (< n 10) (= n (+ n 1)))")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.String() != "10" {
+		t.Fatalf("expected 10, got %v", result)
+	}
+
+	// The budget only applies to EvalWithContext
+	result, err = load(env, "(while (< n 10000) (= n (+ n 1)))")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.String() != "10000" {
+		t.Fatalf("expected 10000, got %v", result)
+	}
+}
@@ -0,0 +1,38 @@
+package environment_test
+
+import (
+	"testing"
+)
+
+func TestClosuresShareCapturedVariables(t *testing.T) {
+	expect(t, `
+(scope
+  (var counter 0)
+  (var increment (lambda () (= counter (+ counter 1))))
+  (var get (lambda () counter))
+  (increment)
+  (increment)
+  (list (get) counter)
+)
+`, "(2 2)")
+}
+
+func TestClosuresSeeEachOthersAssignments(t *testing.T) {
+	expect(t, `
+(var make-cell
+  (lambda (value)
+    (list
+      (lambda () value)
+      (lambda (new) (= value new))
+    )
+  )
+)
+
+(scope
+  (var cell (make-cell 1))
+  (var other (make-cell 1))
+  ((elt cell 1) 42)
+  (list ((elt cell 0)) ((elt other 0)))
+)
+`, "(42 1)")
+}
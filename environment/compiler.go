@@ -0,0 +1,536 @@
+package environment
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// Compiler turns forms into a tree of closures
+type Compiler struct {
+	env    *Environment
+	layout *scope.Layout
+	pos    *cons.Position
+}
+
+// NewCompiler returns a new compiler for the current scope of this
+// environment
+func (env *Environment) NewCompiler() environment.Compiler {
+	var layout *scope.Layout
+
+	s := env.CurrentScope()
+	if s != nil {
+		layout = s.Layout
+	}
+
+	return &Compiler{
+		env:    env,
+		layout: layout,
+	}
+}
+
+// Environment returns the environment the compiler belongs to
+func (c *Compiler) Environment() environment.Environment {
+	return c.env
+}
+
+// Layout returns the layout of the scope the compiler compiles for
+func (c *Compiler) Layout() *scope.Layout {
+	return c.layout
+}
+
+// Position returns the position of the innermost form being compiled
+func (c *Compiler) Position() *cons.Position {
+	return c.pos
+}
+
+// NewScope returns a compiler for a new nested scope
+func (c *Compiler) NewScope() environment.Compiler {
+	return &Compiler{
+		env:    c.env,
+		layout: scope.NewLayout(c.layout),
+		pos:    c.pos,
+	}
+}
+
+// Define a variable in the scope of the compiler
+func (c *Compiler) Define(sym *symbols.Symbol) environment.Reference {
+	if sym.Special {
+		return &specialReference{sym: sym}
+	}
+
+	if c.layout == nil {
+		return &globalReference{sym: sym}
+	}
+
+	return &lexicalReference{
+		sym:  sym,
+		slot: c.layout.Define(sym),
+	}
+}
+
+// Resolve a variable visible from the scope of the compiler, if the variable
+// is not lexically visible it is looked up at runtime
+func (c *Compiler) Resolve(sym *symbols.Symbol) environment.Reference {
+	if sym.Special {
+		return &specialReference{sym: sym}
+	}
+
+	depth, slot, ok := c.layout.Resolve(sym)
+	if !ok {
+		return unresolvedReference(sym)
+	}
+
+	return &lexicalReference{
+		sym:   sym,
+		depth: depth,
+		slot:  slot,
+	}
+}
+
+// unresolvedReference returns the reference for a variable which could not
+// be resolved at compile time
+func unresolvedReference(sym *symbols.Symbol) environment.Reference {
+	if sym.Alias != nil {
+		return &aliasReference{sym: sym}
+	}
+
+	return &globalReference{sym: sym}
+}
+
+// Compile compiles a form
+func (c *Compiler) Compile(obj types.Object) environment.Closure {
+	return c.compile(obj, false)
+}
+
+// CompileTail compiles a form in tail position
+func (c *Compiler) CompileTail(obj types.Object) environment.Closure {
+	return c.compile(obj, true)
+}
+
+// CompileBody compiles a sequence of forms
+func (c *Compiler) CompileBody(body *cons.Cons) environment.Closure {
+	if body == nil {
+		return constantClosure(types.NIL)
+	}
+
+	closures := []environment.Closure{}
+
+	for e := body; ; e = e.Cdr.(*cons.Cons) {
+		if e.Cdr.Type() != types.Cons {
+			// Last form is in tail position
+			closures = append(closures, c.CompileTail(e.Car))
+			break
+		}
+
+		closures = append(closures, c.Compile(e.Car))
+	}
+
+	if len(closures) == 1 {
+		return closures[0]
+	}
+
+	last := len(closures) - 1
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		for _, closure := range closures[:last] {
+			_, err := closure(env, context)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return closures[last](env, context)
+	}
+}
+
+func (c *Compiler) compile(obj types.Object, tail bool) environment.Closure {
+	switch obj.Type() {
+	case types.Symbol:
+		return c.compileSymbol(obj.(*symbols.Symbol))
+	case types.Cons:
+		form := obj.(*cons.Cons)
+		if form.Pos != nil {
+			pos := c.pos
+			c.pos = form.Pos
+
+			defer func() {
+				c.pos = pos
+			}()
+
+			return withPosition(c.compileCall(form, tail), form.Pos)
+		}
+
+		return c.compileCall(form, tail)
+	}
+
+	return constantClosure(obj)
+}
+
+func (c *Compiler) compileSymbol(sym *symbols.Symbol) environment.Closure {
+	if sym.Special {
+		ref := &specialReference{sym: sym}
+
+		return func(env environment.Environment, context interface{}) (types.Object, error) {
+			return ref.Get(env)
+		}
+	}
+
+	depth, slot, ok := c.layout.Resolve(sym)
+	if !ok {
+		// Reserved symbols can only be bound in the global scope, except
+		// for &SELF which is bound by lambda functions
+		if sym.Reserved {
+			return func(env environment.Environment, context interface{}) (types.Object, error) {
+				result := env.GetGlobalBinding(sym)
+				if result == nil {
+					return nil, errors.Errorf(errors.UnboundSymbolError, "unbound symbol %v", sym)
+				}
+
+				return result, nil
+			}
+		}
+
+		ref := unresolvedReference(sym)
+
+		return func(env environment.Environment, context interface{}) (types.Object, error) {
+			return ref.Get(env)
+		}
+	}
+
+	ref := &lexicalReference{
+		sym:   sym,
+		depth: depth,
+		slot:  slot,
+	}
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		return ref.Get(env)
+	}
+}
+
+// resolveFunction returns the function bound to a reserved symbol, reserved
+// symbols can't be rebound so the function can be resolved at compile time
+func (c *Compiler) resolveFunction(obj types.Object) function.Function {
+	sym, ok := obj.(*symbols.Symbol)
+	if !ok || !sym.Reserved {
+		return nil
+	}
+
+	_, _, ok = c.layout.Resolve(sym)
+	if ok {
+		return nil
+	}
+
+	fun, ok := c.env.globalScope[sym].(function.Function)
+	if !ok {
+		return nil
+	}
+
+	return fun
+}
+
+// CompileArgs compiles each form of a list
+func (c *Compiler) CompileArgs(args *cons.Cons) []environment.Closure {
+	closures := []environment.Closure{}
+
+	if args != nil {
+		_ = args.Iter(func(obj types.Object, index interface{}) (bool, error) {
+			closures = append(closures, c.Compile(obj))
+			return false, nil
+		})
+	}
+
+	return closures
+}
+
+func (c *Compiler) compileCall(form *cons.Cons, tail bool) environment.Closure {
+	// Check for pure and get length
+	pure, length := form.Info()
+	if !pure {
+		return errorClosure(errors.New(errors.GeneralError, "eval can't evaluate a dotted list"))
+	}
+
+	var rawArgs *cons.Cons
+	if form.Cdr != types.NIL {
+		rawArgs = form.Cdr.(*cons.Cons)
+	}
+
+	fun := c.resolveFunction(form.Car)
+	if fun != nil {
+		// Check the number of arguments
+		err := functions.CheckArity(functions.Name(fun, form.Car), fun, int(length-1))
+		if err != nil {
+			return errorClosure(err)
+		}
+
+		if compilable, ok := fun.(function.Compilable); ok {
+			closure, err := compilable.Compile(rawArgs, c)
+			if err != nil {
+				return errorClosure(err)
+			}
+
+			if tail {
+				return closure
+			}
+
+			return func(env environment.Environment, context interface{}) (types.Object, error) {
+				result, err := closure(env, context)
+				if err != nil {
+					return nil, err
+				}
+
+				return resolveTailCalls(result, env, context)
+			}
+		}
+
+		if !fun.EvalArgs() {
+			return func(env environment.Environment, context interface{}) (types.Object, error) {
+				result, err := call(fun, form, rawArgs, env, context, tail)
+				if err != nil {
+					return nil, withFrame(err, form, fun, rawArgs)
+				}
+
+				return result, nil
+			}
+		}
+
+		argClosures := c.CompileArgs(rawArgs)
+
+		return func(env environment.Environment, context interface{}) (types.Object, error) {
+			args, err := evalArgs(argClosures, env, context)
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := call(fun, form, args, env, context, tail)
+			if err != nil {
+				return nil, withFrame(err, form, fun, args)
+			}
+
+			return result, nil
+		}
+	}
+
+	// The function is only known at runtime
+	head := c.Compile(form.Car)
+
+	// Arguments are compiled on first use, macros and special forms
+	// receive the unevaluated arguments
+	var argClosures []environment.Closure
+
+	// A macro call is expanded on first use, the compiled expansion is
+	// reused as long as the head evaluates to the same macro
+	var expandedMacro *functions.MacroFunction
+	var expansion environment.Closure
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		r, err := head(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		// Must be a function
+		if r.Type() != types.Function {
+			return nil, errors.Errorf(errors.TypeError, "eval %v is not a function", r)
+		}
+
+		fun := r.(function.Function)
+
+		// Check the number of arguments
+		err = functions.CheckArity(functions.Name(fun, form.Car), fun, int(length-1))
+		if err != nil {
+			return nil, err
+		}
+
+		if macro, ok := fun.(*functions.MacroFunction); ok {
+			if macro != expandedMacro {
+				expansion, err = c.compileExpansion(macro, form, tail, env, context)
+				if err != nil {
+					return nil, withFrame(err, form, fun, rawArgs)
+				}
+
+				expandedMacro = macro
+			}
+
+			result, err := evalExpansion(expansion, env, context)
+			if err != nil {
+				return nil, withFrame(err, form, fun, rawArgs)
+			}
+
+			return result, nil
+		}
+
+		args := rawArgs
+
+		// If we need to first evaluate all args
+		if fun.EvalArgs() && rawArgs != nil {
+			if argClosures == nil {
+				argClosures = c.CompileArgs(rawArgs)
+			}
+
+			args, err = evalArgs(argClosures, env, context)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result, err := call(fun, form, args, env, context, tail)
+		if err != nil {
+			return nil, withFrame(err, form, fun, args)
+		}
+
+		return result, nil
+	}
+}
+
+// withFrame adds a function call to the stack of an error, the function is
+// named after its own name, the symbol it was called with or the position of
+// the lambda
+func withFrame(err error, form *cons.Cons, fun function.Function, args *cons.Cons) error {
+	return errors.WithFrame(err, &errors.Frame{
+		Name: functions.Name(fun, form.Car),
+		Args: args,
+		Pos:  form.Pos,
+	})
+}
+
+func evalArgs(closures []environment.Closure, env environment.Environment, context interface{}) (*cons.Cons, error) {
+	builder := cons.ListBuilder{}
+
+	for _, closure := range closures {
+		obj, err := closure(env, context)
+		if err != nil {
+			return nil, err
+		}
+
+		builder.PushBackObject(obj)
+	}
+
+	return builder.Head, nil
+}
+
+// compileExpansion expands a macro call form and compiles the expansion in
+// the scope of the call, the expansion takes the place of the call so it is
+// compiled in the same position
+func (c *Compiler) compileExpansion(macro *functions.MacroFunction, form *cons.Cons, tail bool, env environment.Environment, context interface{}) (environment.Closure, error) {
+	expanded, err := macro.Expand(form, env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	if tail {
+		return c.CompileTail(expanded), nil
+	}
+
+	return c.Compile(expanded), nil
+}
+
+// evalExpansion evaluates a compiled macro expansion, the expansion can call
+// the macro again, so the evaluation counts towards the call depth
+func evalExpansion(expansion environment.Closure, env environment.Environment, context interface{}) (types.Object, error) {
+	err := env.Step()
+	if err != nil {
+		return nil, err
+	}
+
+	env.PushDepthContext("ExpansionDepth")
+	defer env.PopDepthContext("ExpansionDepth")
+
+	return expansion(env, context)
+}
+
+// call evaluates a function call, in tail position a call to a lambda
+// function is returned as a pending tail call
+func call(fun function.Function, form *cons.Cons, args *cons.Cons, env environment.Environment, context interface{}, tail bool) (types.Object, error) {
+	err := env.Step()
+	if err != nil {
+		return nil, err
+	}
+
+	if tail {
+		if _, ok := fun.(*functions.LambdaFunction); ok {
+			return &function.TailCall{
+				Function: fun,
+				Args:     args,
+			}, nil
+		}
+
+		return eval(fun, form, args, env, context)
+	}
+
+	result, err := eval(fun, form, args, env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveTailCalls(result, env, context)
+}
+
+// eval evaluates fun with args, a macro gets the call form so the form can
+// be bound to its &WHOLE parameter
+func eval(fun function.Function, form *cons.Cons, args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	if macro, ok := fun.(*functions.MacroFunction); ok {
+		return macro.EvalForm(form, env, context)
+	}
+
+	return fun.Eval(args, env, context)
+}
+
+// resolveTailCalls evaluates pending tail calls until a result is reached
+func resolveTailCalls(result types.Object, env environment.Environment, context interface{}) (types.Object, error) {
+	var err error
+
+	for {
+		tailCall, ok := result.(*function.TailCall)
+		if !ok {
+			break
+		}
+
+		result, err = tailCall.Function.Eval(tailCall.Args, env, context)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// withPosition adds the position of a form to errors, errors from nested
+// forms keep the position of the innermost form
+func withPosition(closure environment.Closure, pos *cons.Position) environment.Closure {
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		result, err := closure(env, context)
+		if err != nil {
+			return nil, errors.WithPosition(err, pos)
+		}
+
+		return result, nil
+	}
+}
+
+func constantClosure(obj types.Object) environment.Closure {
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		return obj, nil
+	}
+}
+
+// errorClosure defers a compile error until the form is evaluated, every
+// evaluation returns a new copy of the error because the position and the
+// frames of the evaluation are added to the returned error
+func errorClosure(err error) environment.Closure {
+	_, compileErr := errors.Find(err)
+
+	return func(env environment.Environment, context interface{}) (types.Object, error) {
+		e := compileErr.Copy()
+		e.Pos = compileErr.Pos
+		e.Stack = append([]*errors.Frame{}, compileErr.Stack...)
+
+		return nil, e
+	}
+}
@@ -0,0 +1,29 @@
+package environment_test
+
+import "testing"
+
+func TestCond(t *testing.T) {
+	expect(t, "(cond ((eql 1 2) 'a) ((eql 1 1) 'b) (t 'c))", "B")
+	expect(t, "(cond ((eql 1 2) 'a))", "NIL")
+	expect(t, "(cond (5))", "5")
+}
+
+func TestWhenAndUnless(t *testing.T) {
+	expect(t, "(when t 1 2)", "2")
+	expect(t, "(when nil 1)", "NIL")
+	expect(t, "(unless nil 1 2)", "2")
+	expect(t, "(unless t 1)", "NIL")
+}
+
+func TestCase(t *testing.T) {
+	expect(t, "(case 2 (1 'one) ((2 3) 'two-or-three) (otherwise 'other))", "TWO-OR-THREE")
+	expect(t, "(case 9 (1 'one) (otherwise 'other))", "OTHER")
+	expect(t, "(case 9 (1 'one))", "NIL")
+	expect(t, "(case :a (:a 'key))", "KEY")
+	expectError(t, "(ecase 9 (1 'one))", "no ECASE clause matches 9")
+}
+
+func TestTypecase(t *testing.T) {
+	expect(t, "(typecase \"s\" (:number 'n) (:string 's))", "S")
+	expect(t, "(typecase '(1) (:number 'n) (otherwise 'o))", "O")
+}
@@ -0,0 +1,63 @@
+package environment_test
+
+import (
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+func TestSignalSavedConditionAgain(t *testing.T) {
+	env := environment.New()
+
+	_, err := load(env, `
+(defun fail () (error "failed"))
+(var saved (try (fail) (lambda (c) c)))
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = load(env, `
+(defun fail-again ()
+  (error saved))
+(fail-again)
+`)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	e := errors.From(err)
+
+	if e.Pos == nil || e.Pos.Line != 3 {
+		t.Fatalf("expected the position of the second signal, got %v", e.Pos)
+	}
+
+	if len(e.Stack) != 2 || e.Stack[1].Name != "FAIL-AGAIN" {
+		t.Fatalf("expected only the frames of the second signal, got %v", e.Stack)
+	}
+
+	saved, err := load(env, "saved")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e == saved {
+		t.Fatal("expected a copy of the saved condition")
+	}
+
+	if pos := saved.(*errors.Error).Pos; pos == nil || pos.Line != 2 {
+		t.Fatalf("expected the saved condition to keep its position, got %v", pos)
+	}
+}
+
+func TestHandlerCaseClauseArguments(t *testing.T) {
+	expect(t, `(handler-case (error "failed") (:error () 1))`, "1")
+	expect(t, `(handler-case (error "failed") (:error (c) (condition-message c)))`, `"failed"`)
+}
+
+func TestTryClauseArguments(t *testing.T) {
+	expect(t, "(try (throw 5) ((:number () 1)))", "1")
+	expect(t, "(try (throw 5) ((:number (x) x)))", "5")
+	expect(t, "(try (throw 5) (lambda () 2))", "2")
+}
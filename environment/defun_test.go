@@ -0,0 +1,35 @@
+package environment_test
+
+import (
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+func TestDefun(t *testing.T) {
+	expect(t, "(defun add (a b) \"Adds a and b\" (+ a b)) (add 1 2)", "3")
+	expect(t, "(defun add (a b) \"Adds a and b\" (+ a b)) add", "lambda(ADD)")
+	expect(t, "(defun add (a b) \"Adds a and b\" (+ a b)) (documentation add)", `"Adds a and b"`)
+	expect(t, "(defun add (a b) (+ a b)) (list (function-name add) (function-lambda-list add))", "(ADD (A B))")
+}
+
+func TestDefmacro(t *testing.T) {
+	expect(t, "(defmacro twice (x) \"Doubles x\" (list '+ x x)) (twice 3)", "6")
+	expect(t, "(defmacro twice (x) \"Doubles x\" (list '+ x x)) twice", "macro(TWICE)")
+	expect(t, "(defmacro twice (x) \"Doubles x\" (list '+ x x)) (documentation twice)", `"Doubles x"`)
+}
+
+func TestDefunNamesErrors(t *testing.T) {
+	expectError(t, "(defun add (a b) (+ a b)) (add 1)", "ADD expected 2 arguments, got 1")
+
+	_, err := load(environment.New(), "(defun fail () (error \"failed\")) (fail)")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	e := errors.From(err)
+	if len(e.Stack) != 2 || e.Stack[1].Name != "FAIL" {
+		t.Fatalf("expected a stack frame named FAIL, got %v", e.Stack)
+	}
+}
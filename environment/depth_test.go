@@ -0,0 +1,58 @@
+package environment_test
+
+import (
+	goErrors "errors"
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/types/functions"
+)
+
+const deepRecursion = "(defun deep (n) (if (eql n 0) 0 (+ 1 (deep (- n 1)))))"
+
+func TestDeepRecursionExceedsCallDepth(t *testing.T) {
+	_, err := load(environment.New(), deepRecursion+" (deep 100000)")
+	if !goErrors.Is(err, functions.ErrStackDepthExceeded) {
+		t.Fatalf("expected stack depth exceeded, got %v", err)
+	}
+}
+
+func TestSetMaxCallDepth(t *testing.T) {
+	env := environment.New()
+	env.SetMaxCallDepth(50)
+
+	result, err := load(env, deepRecursion+" (deep 40)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.String() != "40" {
+		t.Fatalf("expected 40, got %v", result)
+	}
+
+	_, err = load(env, "(deep 60)")
+	if !goErrors.Is(err, functions.ErrStackDepthExceeded) {
+		t.Fatalf("expected stack depth exceeded, got %v", err)
+	}
+}
+
+func TestNestedEvalExceedsCallDepth(t *testing.T) {
+	_, err := load(environment.New(), "(var s '(eval s)) (eval s)")
+	if !goErrors.Is(err, functions.ErrStackDepthExceeded) {
+		t.Fatalf("expected stack depth exceeded, got %v", err)
+	}
+}
+
+func TestCallDepthErrorIsNotCaught(t *testing.T) {
+	tests := []string{
+		"(try (deep 100000) (lambda (c) :caught))",
+		"(handler-case (deep 100000) (:error () :caught))",
+	}
+
+	for _, src := range tests {
+		_, err := load(environment.New(), deepRecursion+src)
+		if !goErrors.Is(err, functions.ErrStackDepthExceeded) {
+			t.Fatalf("%v: expected stack depth exceeded, got %v", src, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package environment_test
+
+import (
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/types/dictionaries"
+)
+
+func TestEmptyDictionary(t *testing.T) {
+	result, err := load(environment.New(), "(dictionary)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dictionary, ok := result.(dictionaries.Dictionary)
+	if !ok {
+		t.Fatalf("expected a dictionary, got %v", result)
+	}
+
+	if len(dictionary) != 0 {
+		t.Fatalf("expected an empty dictionary, got %v", dictionary)
+	}
+}
+
+func TestDictionary(t *testing.T) {
+	expect(t, "(dictionary '(a 1))", "(dictionary (A 1))")
+}
+
+func TestDictionaryListKey(t *testing.T) {
+	expect(t, "(var d (dictionary (list '(1 2) :a))) (elt d '(1 2))", "A")
+}
+
+func TestDictionarySpecialSymbolKey(t *testing.T) {
+	expect(t, "(var d (dictionary (list 'foo 1))) (defvar foo 5) (elt d 'foo)", "1")
+}
@@ -1,22 +1,23 @@
 package environment
 
 import (
-	"container/list"
-	"errors"
+	goContext "context"
+	goErrors "errors"
 	"fmt"
 
 	namespacesSetup "github.com/almerlucke/glisp/environment/namespaces"
 
-	"github.com/almerlucke/glisp/interfaces/function"
 	"github.com/almerlucke/glisp/interfaces/namespace"
 	"github.com/almerlucke/glisp/scope"
 	"github.com/almerlucke/glisp/types"
-	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/functions"
 	"github.com/almerlucke/glisp/types/namespaces"
 	"github.com/almerlucke/glisp/types/symbols"
 )
 
-// Environment holds the currently defined symbols and the binding scopes
+// Environment holds the currently defined symbols and the binding scopes,
+// an environment is not safe for concurrent use, see Locked
 type Environment struct {
 	// Symbol table holds all defined symbols in the environment
 	symTable map[string]*symbols.Symbol
@@ -24,16 +25,19 @@ type Environment struct {
 	// gensymCounter is used to create a unique uninterned symbol
 	gensymCounter uint64
 
-	// Scopes can be nested
-	scopes *list.List
+	// Stack of active scopes, the last scope is the current scope, an
+	// empty stack means we are evaluating in the global scope
+	scopes []*scope.Scope
 
-	// Global scope is always at the end of the list, but also keep
-	// a reference here for ease
-	globalScope scope.Scope
+	// Global bindings
+	globalScope map[*symbols.Symbol]types.Object
 
 	// Context can hold all kinds of values
 	context map[string]interface{}
 
+	// Maximum depth of nested function calls, 0 means no limit
+	maxCallDepth uint64
+
 	// Currently used namespace
 	currentNamespace namespace.Namespace
 
@@ -42,19 +46,55 @@ type Environment struct {
 
 	// all namespaces
 	namespaces map[string]namespace.Namespace
+
+	// Context and step budget of the current evaluation, set by
+	// EvalWithContext
+	evalContext goContext.Context
+	stepBudget  uint64
+	steps       uint64
+
+	// Steps are not counted while suspended is not 0
+	suspended uint64
+}
+
+// DefaultMaxCallDepth is the maximum depth of nested function calls of a new
+// environment
+const DefaultMaxCallDepth = 10000
+
+// ErrStepBudgetExceeded is the cause of a CancelledError when an evaluation
+// needs more steps than its budget allows
+var ErrStepBudgetExceeded = goErrors.New("step budget exceeded")
+
+// CancelledError is returned when an evaluation is stopped before it is
+// finished, Err is context.Canceled, context.DeadlineExceeded or
+// ErrStepBudgetExceeded
+type CancelledError struct {
+	Err error
+}
+
+// Error for error interface
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("evaluation cancelled: %v", e.Err)
+}
+
+// Unwrap returns the cause of the cancellation
+func (e *CancelledError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors.ErrCancelled so the error can't be handled by glisp code
+func (e *CancelledError) Is(target error) bool {
+	return target == errors.ErrCancelled
 }
 
 // New returns a new default environment
 func New() *Environment {
-	globalScope := make(scope.Scope)
-	scopes := list.New()
-	scopes.PushFront(globalScope)
-
 	env := &Environment{
-		globalScope: globalScope,
+		globalScope: map[*symbols.Symbol]types.Object{},
 		namespaces:  map[string]namespace.Namespace{},
-		scopes:      scopes,
 		context:     map[string]interface{}{},
+
+		maxCallDepth: DefaultMaxCallDepth,
 	}
 
 	glispNS := namespacesSetup.CreateGlispNamespace(env)
@@ -122,83 +162,104 @@ func (env *Environment) Gensym() *symbols.Symbol {
 	}
 }
 
-// CurrentScope returns the current scope
-func (env *Environment) CurrentScope() scope.Scope {
-	return env.scopes.Front().Value.(scope.Scope)
-}
+// CurrentScope returns the current scope, nil in the global scope
+func (env *Environment) CurrentScope() *scope.Scope {
+	n := len(env.scopes)
+	if n == 0 {
+		return nil
+	}
 
-// PopScope pop a scope
-func (env *Environment) PopScope() scope.Scope {
-	return env.scopes.Remove(env.scopes.Front()).(scope.Scope)
+	return env.scopes[n-1]
 }
 
-// PushScope push a scope, if scope is nil create a new one
-func (env *Environment) PushScope(s scope.Scope) scope.Scope {
-	if s == nil {
-		s = make(scope.Scope)
-	}
-
-	env.scopes.PushFront(s)
+// PopScope pop a scope
+func (env *Environment) PopScope() *scope.Scope {
+	n := len(env.scopes) - 1
+	s := env.scopes[n]
+	env.scopes[n] = nil
+	env.scopes = env.scopes[:n]
 
 	return s
 }
 
-// CaptureScope captures the scope stack flattened except for the global scope
-// if a symbol is shadowed, only capture the topmost binding
-func (env *Environment) CaptureScope() scope.Scope {
-	s := make(scope.Scope)
+// PushScope push a scope, if scope is nil create a new one nested in the
+// current scope
+func (env *Environment) PushScope(s *scope.Scope) *scope.Scope {
+	if s == nil {
+		current := env.CurrentScope()
 
-	for e := env.scopes.Front(); e.Next() != nil; e = e.Next() {
-		otherScope := e.Value.(scope.Scope)
-		for sym, val := range otherScope {
-			if s[sym] == nil {
-				s[sym] = val
-			}
+		var parent *scope.Layout
+		if current != nil {
+			parent = current.Layout
 		}
+
+		s = scope.New(scope.NewLayout(parent), current)
 	}
 
+	env.scopes = append(env.scopes, s)
+
 	return s
 }
 
+// CaptureScope captures the current scope, bindings are not copied so
+// variables are shared with the captured scope
+func (env *Environment) CaptureScope() *scope.Scope {
+	return env.CurrentScope()
+}
+
 // AddGlobalBinding bind object to symbol in the global scope
 func (env *Environment) AddGlobalBinding(sym *symbols.Symbol, obj types.Object) {
+	// Name builtins so errors raised when they are applied from Go, for
+	// example by MAP, can refer to them
+	if fun, ok := obj.(*functions.BuiltinFunction); ok && fun.Name() == "" {
+		fun.SetName(sym.String())
+	}
+
 	env.globalScope[sym] = obj
 }
 
+// GetGlobalBinding get binding for symbol in the global scope
+func (env *Environment) GetGlobalBinding(sym *symbols.Symbol) types.Object {
+	return env.globalScope[sym]
+}
+
 // AddBinding bind object to symbol in the current scope
 func (env *Environment) AddBinding(sym *symbols.Symbol, obj types.Object) {
-	env.scopes.Front().Value.(scope.Scope)[sym] = obj
+	s := env.CurrentScope()
+	if s == nil {
+		env.globalScope[sym] = obj
+		return
+	}
+
+	s.Set(s.Layout.Define(sym), obj)
 }
 
-// GetBinding get binding for symbol
+// GetBinding get binding for symbol, this looks up the symbol by name and
+// is only used for variables that could not be resolved at compile time
 func (env *Environment) GetBinding(sym *symbols.Symbol) types.Object {
-	var obj types.Object
-
-	// Go through scopes to find binding
-	for e := env.scopes.Front(); e != nil; e = e.Next() {
-		s := e.Value.(scope.Scope)
-		obj = s[sym]
-		if obj != nil {
-			break
-		}
+	s, slot, ok := env.CurrentScope().Lookup(sym)
+	if ok {
+		return s.Get(slot)
 	}
 
-	return obj
+	return env.globalScope[sym]
 }
 
 // SetBinding set binding for an already defined symbol
 func (env *Environment) SetBinding(sym *symbols.Symbol, obj types.Object) error {
-	// Go through scopes to find binding
-	for e := env.scopes.Front(); e != nil; e = e.Next() {
-		s := e.Value.(scope.Scope)
-		_, ok := s[sym]
-		if ok {
-			s[sym] = obj
-			return nil
-		}
+	s, slot, ok := env.CurrentScope().Lookup(sym)
+	if ok {
+		s.Set(slot, obj)
+		return nil
 	}
 
-	return fmt.Errorf("unbound symbol %v", sym)
+	_, ok = env.globalScope[sym]
+	if ok {
+		env.globalScope[sym] = obj
+		return nil
+	}
+
+	return errors.Errorf(errors.UnboundSymbolError, "unbound symbol %v", sym)
 }
 
 // FindSymbol returns a symbol or nil if not found
@@ -273,69 +334,86 @@ func (env *Environment) HasDepthContext(d string) bool {
 	return ok && ctx.(uint64) > 0
 }
 
-// Eval evaluates an object with this environment
-func (env *Environment) Eval(obj types.Object, context interface{}) (types.Object, error) {
-	result := obj
+// DepthContext returns the depth of a specific depth context type
+func (env *Environment) DepthContext(d string) uint64 {
+	ctx, ok := env.context[d]
+	if ok {
+		return ctx.(uint64)
+	}
 
-	switch obj.Type() {
+	return 0
+}
 
-	case types.Symbol:
-		result = env.GetBinding(obj.(*symbols.Symbol))
-		if result == nil {
-			return nil, fmt.Errorf("unbound symbol %v", obj)
-		}
+// MaxCallDepth returns the maximum depth of nested function calls
+func (env *Environment) MaxCallDepth() uint64 {
+	return env.maxCallDepth
+}
 
-	case types.Cons:
-		// List to evaluate
-		c := obj.(*cons.Cons)
+// SetMaxCallDepth sets the maximum depth of nested function calls, calls
+// beyond the maximum return an error instead of overflowing the Go stack,
+// 0 means no limit
+func (env *Environment) SetMaxCallDepth(max uint64) {
+	env.maxCallDepth = max
+}
 
-		// Evaluate first elem
-		r, err := env.Eval(c.Car, context)
-		if err != nil {
-			return nil, err
-		}
+// Eval evaluates an object with this environment, the object is compiled
+// first and pending tail calls are resolved before returning the result
+func (env *Environment) Eval(obj types.Object, context interface{}) (types.Object, error) {
+	return env.NewCompiler().Compile(obj)(env, context)
+}
 
-		// Must be a function
-		if r.Type() != types.Function {
-			return nil, fmt.Errorf("eval %v is not a function", r)
-		}
+// EvalWithContext evaluates an object until it is finished, ctx is done or
+// more than budget steps are taken, a budget of 0 means no limit. A step is
+// counted for every function call, loop iteration and loaded form
+func (env *Environment) EvalWithContext(ctx goContext.Context, obj types.Object, context interface{}, budget uint64) (types.Object, error) {
+	evalContext, stepBudget, steps := env.evalContext, env.stepBudget, env.steps
 
-		fun := r.(function.Function)
+	env.evalContext = ctx
+	env.stepBudget = budget
+	env.steps = 0
 
-		// Check for pure and get length
-		pure, length := c.Info()
-		if !pure {
-			return nil, errors.New("eval can't evaluate a dotted list")
-		}
+	defer func() {
+		env.evalContext, env.stepBudget, env.steps = evalContext, stepBudget, steps
+	}()
 
-		// Check if we have enough arguments
-		if (length - 1) < int64(fun.NumArgs()) {
-			return nil, fmt.Errorf("not enough arguments to function %v", c.Car)
-		}
+	return env.Eval(obj, context)
+}
 
-		// If we need to first evaluate all args
-		var args *cons.Cons
-		if c.Cdr != types.NIL {
-			args = c.Cdr.(*cons.Cons)
-			if fun.EvalArgs() {
-				col, serr := args.Map(func(obj types.Object, index interface{}) (types.Object, error) {
-					return env.Eval(obj, context)
-				})
-
-				if serr != nil {
-					return nil, serr
-				}
-
-				args = col.(*cons.Cons)
-			}
-		}
+// Step counts an evaluation step, an error is returned if the evaluation
+// must be stopped
+func (env *Environment) Step() error {
+	if env.evalContext == nil || env.suspended > 0 {
+		return nil
+	}
 
-		// Evaluate function call
-		result, err = fun.Eval(args, env, context)
-		if err != nil {
-			return nil, err
-		}
+	env.steps++
+
+	if env.stepBudget > 0 && env.steps > env.stepBudget {
+		return &CancelledError{Err: ErrStepBudgetExceeded}
 	}
 
-	return result, nil
+	select {
+	case <-env.evalContext.Done():
+		return &CancelledError{Err: env.evalContext.Err()}
+	default:
+	}
+
+	return nil
+}
+
+// SuspendSteps stops counting steps until ResumeSteps is called, the
+// evaluation can't be stopped in between. Calls can be nested
+func (env *Environment) SuspendSteps() {
+	env.suspended++
+}
+
+// ResumeSteps counts steps again after SuspendSteps
+func (env *Environment) ResumeSteps() {
+	env.suspended--
+}
+
+// EvalTail evaluates an object in tail position, a call to a lambda function
+// is not evaluated but returned as a function.TailCall
+func (env *Environment) EvalTail(obj types.Object, context interface{}) (types.Object, error) {
+	return env.NewCompiler().CompileTail(obj)(env, context)
 }
@@ -0,0 +1,30 @@
+package environment_test
+
+import "testing"
+
+func TestBlock(t *testing.T) {
+	expect(t, "(block a (block b (return-from a 1)) 2)", "1")
+	expect(t, "(block nil (return-from nil) 1)", "NIL")
+	expect(t, "(block a 1 2)", "2")
+}
+
+func TestBlockIsLexical(t *testing.T) {
+	expect(t, "(defun each (f) (block nil (f 1) :lib)) (block nil (each (lambda (x) (return-from nil :user))) :after)", "USER")
+	expectError(t, "(defun f () (return-from a 1)) (block a (f))", "RETURN-FROM no block named A")
+	expectError(t, "(var k (block b (lambda () (return-from b 1)))) (k)", "RETURN-FROM block B is no longer active")
+}
+
+func TestTagbody(t *testing.T) {
+	expect(t, "(var n 0) (tagbody start (= n (+ n 1)) (if (< n 3) (go start))) n", "3")
+	expect(t, "(var n 0) (tagbody (go end) (= n 1) end) n", "0")
+}
+
+func TestTagbodyIsLexical(t *testing.T) {
+	expect(t, "(defun walk (f) (tagbody again (f) done)) (var n 0) (tagbody again (= n (+ n 1)) (if (< n 3) (walk (lambda () (go again))))) n", "3")
+	expectError(t, "(defun f () (go a)) (tagbody a (f))", "GO no tagbody with tag A")
+}
+
+func TestCatchIsDynamic(t *testing.T) {
+	expect(t, "(catch :done (+ 1 (catch :inner (throw-tag :done 5))))", "5")
+	expect(t, "(var deep (lambda (n) (if (> n 0) (deep (- n 1)) (throw-tag :deep n)))) (catch :deep (deep 100))", "0")
+}
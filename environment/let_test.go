@@ -0,0 +1,22 @@
+package environment_test
+
+import "testing"
+
+func TestLet(t *testing.T) {
+	expect(t, "(var x 1) (let ((x 2) (y x)) (list x y))", "(2 1)")
+	expect(t, "(var x 1) (let* ((x 2) (y x)) (list x y))", "(2 2)")
+	expect(t, "(let (a (b)) (list a b))", "(NIL NIL)")
+	expect(t, "(var c nil) (let ((x 5)) (= c (lambda () x))) (c)", "5")
+}
+
+func TestLetBindsSpecialVariables(t *testing.T) {
+	expect(t, "(defvar *base* 10) (defun get-base () *base*) (list (let ((*base* 16)) (get-base)) (get-base))", "(16 10)")
+}
+
+func TestFletAndLabels(t *testing.T) {
+	expect(t, "(defun f (n) (* n 10)) (flet ((f (n) (+ n 1)) (g (n) (f n))) (list (f 1) (g 1)))", "(2 10)")
+	expect(t, `
+(labels ((ev? (n) (if (eql n 0) t (od? (- n 1))))
+         (od? (n) (if (eql n 0) nil (ev? (- n 1)))))
+  (list (ev? 10) (od? 10)))`, "(T NIL)")
+}
@@ -0,0 +1,91 @@
+package environment_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/globals/tables"
+	"github.com/almerlucke/glisp/reader"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+// load reads and evaluates all objects in src and returns the result of
+// the last evaluation
+func load(env *environment.Environment, src string) (types.Object, error) {
+	rd := reader.New(strings.NewReader(src), tables.DefaultReadTable, tables.DefaultDispatchTable, env)
+
+	obj, err := rd.ReadObject()
+	var result types.Object = types.NIL
+
+	for err == nil {
+		result, err = env.Eval(obj, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err = rd.ReadObject()
+	}
+
+	if err != io.EOF {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// loadWithContext reads and evaluates all objects in src with
+// EvalWithContext, each evaluation gets its own step budget
+func loadWithContext(env *environment.Environment, ctx context.Context, budget uint64, src string) (types.Object, error) {
+	rd := reader.New(strings.NewReader(src), tables.DefaultReadTable, tables.DefaultDispatchTable, env)
+
+	obj, err := rd.ReadObject()
+	var result types.Object = types.NIL
+
+	for err == nil {
+		result, err = env.EvalWithContext(ctx, obj, nil, budget)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err = rd.ReadObject()
+	}
+
+	if err != io.EOF {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// expect loads src in a new environment and checks the printed result
+func expect(t *testing.T, src string, expected string) {
+	t.Helper()
+
+	result, err := load(environment.New(), src)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if result.String() != expected {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+// expectError loads src in a new environment and checks the message of the
+// returned error
+func expectError(t *testing.T, src string, message string) {
+	t.Helper()
+
+	_, err := load(environment.New(), src)
+	if err == nil {
+		t.Fatalf("expected error %q", message)
+	}
+
+	if e := errors.From(err); e == nil || e.Message != message {
+		t.Fatalf("expected error %q, got %v", message, err)
+	}
+}
@@ -0,0 +1,66 @@
+package environment
+
+import (
+	"bufio"
+	goContext "context"
+	"io"
+	"sync"
+
+	"github.com/almerlucke/glisp/builtin"
+	"github.com/almerlucke/glisp/globals/tables"
+	"github.com/almerlucke/glisp/reader"
+	"github.com/almerlucke/glisp/types"
+)
+
+// Locked wraps an environment so it can be shared between goroutines, an
+// environment itself is not safe for concurrent use. Separate environments
+// don't share any mutable state and don't need to be locked
+type Locked struct {
+	mutex sync.Mutex
+	env   *Environment
+}
+
+// NewLocked returns a locked wrapper for env, env must not be used directly
+// while it is shared
+func NewLocked(env *Environment) *Locked {
+	return &Locked{
+		env: env,
+	}
+}
+
+// Do calls fn with the environment while holding the lock, use it to read
+// objects with a reader because reading interns symbols in the environment
+func (l *Locked) Do(fn func(env *Environment) error) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return fn(l.env)
+}
+
+// Eval evaluates an object while holding the lock
+func (l *Locked) Eval(obj types.Object, context interface{}) (types.Object, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.env.Eval(obj, context)
+}
+
+// EvalWithContext evaluates an object with a context and step budget while
+// holding the lock
+func (l *Locked) EvalWithContext(ctx goContext.Context, obj types.Object, context interface{}, budget uint64) (types.Object, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.env.EvalWithContext(ctx, obj, context, budget)
+}
+
+// Load reads and evaluates all objects from r while holding the lock and
+// returns the result of the last evaluation
+func (l *Locked) Load(r io.Reader, context interface{}) (types.Object, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	rd := reader.New(bufio.NewReader(r), tables.DefaultReadTable, tables.DefaultDispatchTable, l.env)
+
+	return builtin.LoadReader(rd, l.env, context)
+}
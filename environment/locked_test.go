@@ -0,0 +1,88 @@
+package environment_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+)
+
+const numGoroutines = 8
+
+// source exercises the reader, macros, conditions and special variables,
+// which all use state of the environment
+const source = `
+(defvar *offset* %d)
+(defmacro twice (form) ` + "`" + `(+ ,form ,form))
+(defun fib (n) (if (< n 2) n (+ (fib (- n 1)) (fib (- n 2)))))
+(var sym (gensym))
+(var total (handler-case (error :oops "failed") (:oops (c) 0)))
+(dolist (x '(1 2 3)) (= total (+ total x)))
+(list :result (+ (fib 15) *offset*) (twice total))
+`
+
+func TestSeparateEnvironmentsConcurrently(t *testing.T) {
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+
+		go func(id int) {
+			defer wg.Done()
+
+			// Separate environments don't need to be locked
+			result, err := load(environment.New(), fmt.Sprintf(source, id))
+			if err != nil {
+				t.Errorf("environment %d error %v", id, err)
+				return
+			}
+
+			expected := fmt.Sprintf("(RESULT %d 12)", 610+id)
+			if result.String() != expected {
+				t.Errorf("environment %d expected %v, got %v", id, expected, result)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func TestLockedEnvironmentConcurrently(t *testing.T) {
+	env := environment.NewLocked(environment.New())
+
+	_, err := env.Load(strings.NewReader("(var counter 0) (defun increment () (= counter (+ counter 1)))"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+
+		go func(id int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				_, err := env.Load(strings.NewReader(fmt.Sprintf("(increment) (var local-%d %d)", id, j)), nil)
+				if err != nil {
+					t.Errorf("goroutine %d error %v", id, err)
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	result, err := env.Load(strings.NewReader("counter"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.String() != fmt.Sprint(numGoroutines*100) {
+		t.Fatalf("expected %v, got %v", numGoroutines*100, result)
+	}
+}
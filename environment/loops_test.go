@@ -0,0 +1,27 @@
+package environment_test
+
+import "testing"
+
+func TestLoops(t *testing.T) {
+	expect(t, "(var n 0) (dotimes (i 4 n) (= n (+ n i)))", "6")
+	expect(t, "(var l nil) (dolist (x '(1 2 3) l) (= l (cons x l)))", "(3 2 1)")
+	expect(t, "(var l nil) (doseq ((x i) '(a b)) (= l (cons i l))) l", "(1 0)")
+	expect(t, "(var n 0) (while (< n 3) (= n (+ n 1)))", "3")
+}
+
+func TestBreakAndContinue(t *testing.T) {
+	expect(t, "(dolist (x '(1 2 3)) (if (eql x 2) (break x)))", "2")
+	expect(t, "(var n 0) (while t (scope (if (eql n 3) (break n)) (= n (+ n 1))))", "3")
+	expect(t, "(var n 0) (dotimes (i 5 n) (if (eql i 2) (continue)) (= n (+ n 1)))", "4")
+	expect(t, "(dotimes (i 2) (dotimes (j 2) (break)) (continue))", "NIL")
+}
+
+func TestBreakAndContinueOutsideLoop(t *testing.T) {
+	expectError(t, "(break)", "BREAK can only be used inside a loop")
+	expectError(t, "(continue)", "CONTINUE can only be used inside a loop")
+}
+
+func TestContinueOutsideLoopBody(t *testing.T) {
+	expectError(t, "(while (continue) 1)", "CONTINUE can only be used in the body of a loop")
+	expectError(t, "(dotimes (i 1 (continue)) i)", "CONTINUE can only be used in the body of a loop")
+}
@@ -0,0 +1,21 @@
+package environment_test
+
+import "testing"
+
+const expandMacros = "(defmacro ++ (x) `(= ,x (+ ,x 1))) (defmacro twice (x) `(+ ,x ,x)) "
+
+func TestMacroexpandAllSkipsLambdaLists(t *testing.T) {
+	expect(t, expandMacros+"(macroexpand-all '(lambda (++ x) x))", "(LAMBDA (++ X) X)")
+	expect(t, expandMacros+"(macroexpand-all '(lambda (a &optional (b (twice 1))) (twice a)))", "(LAMBDA (A &OPTIONAL (B (+ 1 1))) (+ A A))")
+	expect(t, expandMacros+"(macroexpand-all '(defun f (twice) twice))", "(DEFUN F (TWICE) TWICE)")
+}
+
+func TestMacroexpandAllSkipsBindings(t *testing.T) {
+	expect(t, expandMacros+"(macroexpand-all '(let ((++ (twice 1))) ++))", "(LET ((++ (+ 1 1))) ++)")
+	expect(t, expandMacros+"(macroexpand-all '(flet ((f (twice) (twice 2))) (f 1)))", "(FLET ((F (TWICE) (+ 2 2))) (F 1))")
+	expect(t, expandMacros+"(macroexpand-all '(destructuring-bind (a (twice b)) (twice 1) a))", "(DESTRUCTURING-BIND (A (TWICE B)) (+ 1 1) A)")
+}
+
+func TestMacroexpandAllSkipsCaseKeys(t *testing.T) {
+	expect(t, expandMacros+"(macroexpand-all '(case (twice 1) ((twice 2) (twice 3))))", "(CASE (+ 1 1) ((TWICE 2) (+ 3 3)))")
+}
@@ -2,6 +2,7 @@ package namespaces
 
 import (
 	"github.com/almerlucke/glisp/builtin"
+	"github.com/almerlucke/glisp/builtin/conditions"
 	"github.com/almerlucke/glisp/builtin/loops"
 	"github.com/almerlucke/glisp/builtin/numbers"
 	"github.com/almerlucke/glisp/globals/symbols"
@@ -18,6 +19,13 @@ func CreateGlispNamespace(env environment.Environment) namespace.Namespace {
 	glispNS.Add(symbols.NILSymbol, true)
 	glispNS.Add(symbols.TSymbol, true)
 	glispNS.Add(symbols.AndRestSymbol, true)
+	glispNS.Add(symbols.AndOptionalSymbol, true)
+	glispNS.Add(symbols.AndKeySymbol, true)
+	glispNS.Add(symbols.AndAllowOtherKeysSymbol, true)
+	glispNS.Add(symbols.AndBodySymbol, true)
+	glispNS.Add(symbols.AndWholeSymbol, true)
+	glispNS.Add(symbols.AndEnvironmentSymbol, true)
+	glispNS.Add(symbols.OtherwiseSymbol, true)
 	glispNS.Add(symbols.SelfSymbol, true)
 	glispNS.Add(symbols.BackquoteSymbol, true)
 	glispNS.Add(symbols.CloseParenthesisSymbol, true)
@@ -37,12 +45,35 @@ func CreateGlispNamespace(env environment.Environment) namespace.Namespace {
 	env.AddGlobalBinding(glispNS.DefineSymbol("CONS", true, nil, true), builtin.CreateBuiltinCons())
 	env.AddGlobalBinding(glispNS.DefineSymbol("LAMBDA", true, nil, true), builtin.CreateBuiltinLambda())
 	env.AddGlobalBinding(glispNS.DefineSymbol("MACRO", true, nil, true), builtin.CreateBuiltinMacro())
+	env.AddGlobalBinding(glispNS.DefineSymbol("MACROEXPAND-1", true, nil, true), builtin.CreateBuiltinMacroexpand1())
+	env.AddGlobalBinding(glispNS.DefineSymbol("MACROEXPAND", true, nil, true), builtin.CreateBuiltinMacroexpand())
+	env.AddGlobalBinding(glispNS.DefineSymbol("MACROEXPAND-ALL", true, nil, true), builtin.CreateBuiltinMacroexpandAll())
+	env.AddGlobalBinding(glispNS.DefineSymbol("SYNTAX-RULES", true, nil, true), builtin.CreateBuiltinSyntaxRules())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DEFUN", true, nil, true), builtin.CreateBuiltinDefun())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DEFMACRO", true, nil, true), builtin.CreateBuiltinDefmacro())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DOCUMENTATION", true, nil, true), builtin.CreateBuiltinDocumentation())
+	env.AddGlobalBinding(glispNS.DefineSymbol("FUNCTION-NAME", true, nil, true), builtin.CreateBuiltinFunctionName())
+	env.AddGlobalBinding(glispNS.DefineSymbol("FUNCTION-LAMBDA-LIST", true, nil, true), builtin.CreateBuiltinFunctionLambdaList())
 	env.AddGlobalBinding(glispNS.DefineSymbol("GENSYM", true, nil, true), builtin.CreateBuiltinGensym())
 	env.AddGlobalBinding(glispNS.DefineSymbol("PRINT", true, nil, true), builtin.CreateBuiltinPrint())
 	env.AddGlobalBinding(glispNS.DefineSymbol("EXIT", true, nil, true), builtin.CreateBuiltinExit())
 	env.AddGlobalBinding(glispNS.DefineSymbol("RETURN", true, nil, true), builtin.CreateBuiltinReturn())
+	env.AddGlobalBinding(glispNS.DefineSymbol("BLOCK", true, nil, true), builtin.CreateBuiltinBlock())
+	env.AddGlobalBinding(glispNS.DefineSymbol("RETURN-FROM", true, nil, true), builtin.CreateBuiltinReturnFrom())
+	env.AddGlobalBinding(glispNS.DefineSymbol("CATCH", true, nil, true), builtin.CreateBuiltinCatch())
+	env.AddGlobalBinding(glispNS.DefineSymbol("THROW-TAG", true, nil, true), builtin.CreateBuiltinThrowTag())
+	env.AddGlobalBinding(glispNS.DefineSymbol("TAGBODY", true, nil, true), builtin.CreateBuiltinTagbody())
+	env.AddGlobalBinding(glispNS.DefineSymbol("GO", true, nil, true), builtin.CreateBuiltinGo())
 	env.AddGlobalBinding(glispNS.DefineSymbol("LOAD", true, nil, true), builtin.CreateBuiltinLoad())
 	env.AddGlobalBinding(glispNS.DefineSymbol("VAR", true, nil, true), builtin.CreateBuiltinVar())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DEFVAR", true, nil, true), builtin.CreateBuiltinDefvar())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DEFPARAMETER", true, nil, true), builtin.CreateBuiltinDefparameter())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DYNAMIC-LET", true, nil, true), builtin.CreateBuiltinDynamicLet())
+	env.AddGlobalBinding(glispNS.DefineSymbol("LET", true, nil, true), builtin.CreateBuiltinLet())
+	env.AddGlobalBinding(glispNS.DefineSymbol("LET*", true, nil, true), builtin.CreateBuiltinLetStar())
+	env.AddGlobalBinding(glispNS.DefineSymbol("FLET", true, nil, true), builtin.CreateBuiltinFlet())
+	env.AddGlobalBinding(glispNS.DefineSymbol("LABELS", true, nil, true), builtin.CreateBuiltinLabels())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DESTRUCTURING-BIND", true, nil, true), builtin.CreateBuiltinDestructuringBind())
 	env.AddGlobalBinding(glispNS.DefineSymbol("=", true, nil, true), builtin.CreateBuiltinAssign())
 	env.AddGlobalBinding(glispNS.DefineSymbol("SCOPE", true, nil, true), builtin.CreateBuiltinScope())
 	env.AddGlobalBinding(glispNS.DefineSymbol("EVAL", true, nil, true), builtin.CreateBuiltinEval())
@@ -50,9 +81,26 @@ func CreateGlispNamespace(env environment.Environment) namespace.Namespace {
 	env.AddGlobalBinding(glispNS.DefineSymbol("ARRAY", true, nil, true), builtin.CreateBuiltinArray())
 	env.AddGlobalBinding(glispNS.DefineSymbol("MAKE-ARRAY", true, nil, true), builtin.CreateBuiltinMakeArray())
 	env.AddGlobalBinding(glispNS.DefineSymbol("IF", true, nil, true), builtin.CreateBuiltinIf())
+	env.AddGlobalBinding(glispNS.DefineSymbol("COND", true, nil, true), builtin.CreateBuiltinCond())
+	env.AddGlobalBinding(glispNS.DefineSymbol("WHEN", true, nil, true), builtin.CreateBuiltinWhen())
+	env.AddGlobalBinding(glispNS.DefineSymbol("UNLESS", true, nil, true), builtin.CreateBuiltinUnless())
+	env.AddGlobalBinding(glispNS.DefineSymbol("CASE", true, nil, true), builtin.CreateBuiltinCase())
+	env.AddGlobalBinding(glispNS.DefineSymbol("ECASE", true, nil, true), builtin.CreateBuiltinEcase())
+	env.AddGlobalBinding(glispNS.DefineSymbol("TYPECASE", true, nil, true), builtin.CreateBuiltinTypecase())
 	env.AddGlobalBinding(glispNS.DefineSymbol("DO", true, nil, true), builtin.CreateBuiltinDo())
 	env.AddGlobalBinding(glispNS.DefineSymbol("TRY", true, nil, true), builtin.CreateBuiltinTry())
 	env.AddGlobalBinding(glispNS.DefineSymbol("THROW", true, nil, true), builtin.CreateBuiltinThrow())
+	env.AddGlobalBinding(glispNS.DefineSymbol("UNWIND-PROTECT", true, nil, true), builtin.CreateBuiltinUnwindProtect())
+	env.AddGlobalBinding(glispNS.DefineSymbol("HANDLER-BIND", true, nil, true), conditions.CreateBuiltinHandlerBind())
+	env.AddGlobalBinding(glispNS.DefineSymbol("HANDLER-CASE", true, nil, true), conditions.CreateBuiltinHandlerCase())
+	env.AddGlobalBinding(glispNS.DefineSymbol("RESTART-CASE", true, nil, true), conditions.CreateBuiltinRestartCase())
+	env.AddGlobalBinding(glispNS.DefineSymbol("INVOKE-RESTART", true, nil, true), conditions.CreateBuiltinInvokeRestart())
+	env.AddGlobalBinding(glispNS.DefineSymbol("SIGNAL", true, nil, true), conditions.CreateBuiltinSignal())
+	env.AddGlobalBinding(glispNS.DefineSymbol("ERROR", true, nil, true), conditions.CreateBuiltinError())
+	env.AddGlobalBinding(glispNS.DefineSymbol("WARN", true, nil, true), conditions.CreateBuiltinWarn())
+	env.AddGlobalBinding(glispNS.DefineSymbol("CONDITION-TYPE", true, nil, true), conditions.CreateBuiltinConditionType())
+	env.AddGlobalBinding(glispNS.DefineSymbol("CONDITION-MESSAGE", true, nil, true), conditions.CreateBuiltinConditionMessage())
+	env.AddGlobalBinding(glispNS.DefineSymbol("CONDITION-OBJECT", true, nil, true), conditions.CreateBuiltinConditionObject())
 	env.AddGlobalBinding(glispNS.DefineSymbol("DICTIONARY", true, nil, true), builtin.CreateBuiltinDictionary())
 	env.AddGlobalBinding(glispNS.DefineSymbol("AND", true, nil, true), builtin.CreateBuiltinAnd())
 	env.AddGlobalBinding(glispNS.DefineSymbol("OR", true, nil, true), builtin.CreateBuiltinOr())
@@ -67,6 +115,10 @@ func CreateGlispNamespace(env environment.Environment) namespace.Namespace {
 
 	env.AddGlobalBinding(glispNS.DefineSymbol("WHILE", true, nil, true), loops.CreateBuiltinWhile())
 	env.AddGlobalBinding(glispNS.DefineSymbol("BREAK", true, nil, true), loops.CreateBuiltinBreak())
+	env.AddGlobalBinding(glispNS.DefineSymbol("CONTINUE", true, nil, true), loops.CreateBuiltinContinue())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DOTIMES", true, nil, true), loops.CreateBuiltinDotimes())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DOLIST", true, nil, true), loops.CreateBuiltinDolist())
+	env.AddGlobalBinding(glispNS.DefineSymbol("DOSEQ", true, nil, true), loops.CreateBuiltinDoseq())
 
 	env.AddGlobalBinding(glispNS.DefineSymbol("INT8", true, nil, true), numbers.CreateBuiltinInt8())
 	env.AddGlobalBinding(glispNS.DefineSymbol("INT16", true, nil, true), numbers.CreateBuiltinInt16())
@@ -0,0 +1,153 @@
+package environment
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// lexicalReference references a slot in a scope depth levels up from the
+// current scope
+type lexicalReference struct {
+	sym   *symbols.Symbol
+	depth int
+	slot  int
+}
+
+// Get the bound object
+func (ref *lexicalReference) Get(env environment.Environment) (types.Object, error) {
+	obj := env.CurrentScope().Up(ref.depth).Get(ref.slot)
+	if obj == nil {
+		// The variable is not yet bound in its scope, look further
+		obj = env.GetBinding(ref.sym)
+		if obj == nil {
+			return nil, errors.Errorf(errors.UnboundSymbolError, "unbound symbol %v", ref.sym)
+		}
+	}
+
+	return obj, nil
+}
+
+// Bind an object to the slot
+func (ref *lexicalReference) Bind(env environment.Environment, obj types.Object) {
+	env.CurrentScope().Up(ref.depth).Set(ref.slot, obj)
+}
+
+// Set assigns an object to the slot if it is bound
+func (ref *lexicalReference) Set(env environment.Environment, obj types.Object) error {
+	s := env.CurrentScope().Up(ref.depth)
+	if s.Get(ref.slot) == nil {
+		return env.SetBinding(ref.sym, obj)
+	}
+
+	s.Set(ref.slot, obj)
+
+	return nil
+}
+
+// specialReference references a special variable, the current dynamic
+// binding of the variable is held in the global scope
+type specialReference struct {
+	sym *symbols.Symbol
+}
+
+// Get the bound object
+func (ref *specialReference) Get(env environment.Environment) (types.Object, error) {
+	obj := env.GetGlobalBinding(ref.sym)
+	if obj == nil {
+		return nil, errors.Errorf(errors.UnboundSymbolError, "unbound symbol %v", ref.sym)
+	}
+
+	return obj, nil
+}
+
+// Bind an object to the current dynamic binding
+func (ref *specialReference) Bind(env environment.Environment, obj types.Object) {
+	env.AddGlobalBinding(ref.sym, obj)
+}
+
+// Set assigns an object to the current dynamic binding, special variables
+// are declared so they can always be assigned
+func (ref *specialReference) Set(env environment.Environment, obj types.Object) error {
+	env.AddGlobalBinding(ref.sym, obj)
+
+	return nil
+}
+
+// globalReference references a variable which could not be resolved at
+// compile time, it is looked up by symbol at runtime
+type globalReference struct {
+	sym *symbols.Symbol
+}
+
+// Get the bound object
+func (ref *globalReference) Get(env environment.Environment) (types.Object, error) {
+	obj := env.GetBinding(ref.sym)
+	if obj == nil {
+		return nil, errors.Errorf(errors.UnboundSymbolError, "unbound symbol %v", ref.sym)
+	}
+
+	return obj, nil
+}
+
+// Bind an object in the current scope
+func (ref *globalReference) Bind(env environment.Environment, obj types.Object) {
+	env.AddBinding(ref.sym, obj)
+}
+
+// Set assigns an object to a bound variable
+func (ref *globalReference) Set(env environment.Environment, obj types.Object) error {
+	return env.SetBinding(ref.sym, obj)
+}
+
+// aliasReference references a symbol renamed by a hygienic macro expansion
+// which could not be resolved at compile time, if the renamed symbol is not
+// bound at runtime the global binding of its alias is used
+type aliasReference struct {
+	sym *symbols.Symbol
+}
+
+// global returns the first alias of the symbol with a global binding
+func (ref *aliasReference) global(env environment.Environment) (*symbols.Symbol, types.Object) {
+	for alias := ref.sym.Alias; alias != nil; alias = alias.Alias {
+		obj := env.GetGlobalBinding(alias)
+		if obj != nil {
+			return alias, obj
+		}
+	}
+
+	return nil, nil
+}
+
+// Get the bound object
+func (ref *aliasReference) Get(env environment.Environment) (types.Object, error) {
+	obj := env.GetBinding(ref.sym)
+	if obj == nil {
+		_, obj = ref.global(env)
+		if obj == nil {
+			return nil, errors.Errorf(errors.UnboundSymbolError, "unbound symbol %v", ref.sym)
+		}
+	}
+
+	return obj, nil
+}
+
+// Bind an object to the renamed symbol in the current scope
+func (ref *aliasReference) Bind(env environment.Environment, obj types.Object) {
+	env.AddBinding(ref.sym, obj)
+}
+
+// Set assigns an object to the renamed symbol if it is bound, otherwise to
+// the global binding of its alias
+func (ref *aliasReference) Set(env environment.Environment, obj types.Object) error {
+	if env.GetBinding(ref.sym) == nil {
+		alias, _ := ref.global(env)
+		if alias != nil {
+			env.AddGlobalBinding(alias, obj)
+			return nil
+		}
+	}
+
+	return env.SetBinding(ref.sym, obj)
+}
@@ -0,0 +1,22 @@
+package environment_test
+
+import "testing"
+
+func TestDefvarAndDefparameter(t *testing.T) {
+	expect(t, "(defvar *base* 10) (defvar *base* 20) *base*", "10")
+	expect(t, "(defparameter *base* 10) (defparameter *base* 20) *base*", "20")
+}
+
+func TestDynamicLet(t *testing.T) {
+	expect(t, "(defvar *base* 10) (defun get-base () *base*) (list (dynamic-let ((*base* 16)) (get-base)) (get-base))", "(16 10)")
+	expect(t, "(defvar *base* 10) (var get-base (lambda () *base*)) (dynamic-let ((*base* 8)) (get-base))", "8")
+}
+
+func TestDynamicLetRestoresOnExit(t *testing.T) {
+	expect(t, "(defvar *base* 10) (block out (dynamic-let ((*base* 2)) (return-from out *base*))) *base*", "10")
+	expect(t, "(defvar *base* 10) (try (dynamic-let ((*base* 3)) (error \"failed\")) (lambda (c) *base*))", "10")
+}
+
+func TestDynamicLetOnlyBindsSpecialVariables(t *testing.T) {
+	expectError(t, "(var l 1) (dynamic-let ((l 2)) l)", "DYNAMIC-LET can only bind special variables, got L")
+}
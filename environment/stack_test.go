@@ -0,0 +1,28 @@
+package environment_test
+
+import (
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+func TestCompileErrorStackPerCall(t *testing.T) {
+	env := environment.New()
+
+	_, err := load(env, "(var f (lambda () (car)))")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err = load(env, "(f)")
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if stack := errors.From(err).Stack; len(stack) != 1 {
+			t.Fatalf("call %d: expected a single frame, got %v", i+1, stack)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package environment_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+)
+
+func TestSyntaxRulesBackquoteIsData(t *testing.T) {
+	expect(t, "(var bq (syntax-rules () ((_ x) `(q ,x)))) (bq 1)", "(Q 1)")
+	expect(t, "(var bq (syntax-rules () ((_ x) `(q ,@(list x x))))) (bq 1)", "(Q 1 1)")
+}
+
+func TestSyntaxRulesCaseKeysAreData(t *testing.T) {
+	expect(t, "(var k (syntax-rules () ((_ x) (case x ((a b) 1) (c 2) (otherwise 3))))) (list (k 'a) (k 'c) (k 'd))", "(1 2 3)")
+	expect(t, "(var k (syntax-rules () ((_ x (key val) ...) (ecase x (key val) ...)))) (k 'b (a 1) (b 2))", "2")
+}
+
+func TestSyntaxRulesRejectsLocalVariables(t *testing.T) {
+	_, err := load(environment.New(), "(let ((y 1)) (var m (syntax-rules () ((_) y))) (m))")
+	if err == nil || !strings.Contains(err.Error(), "refers to local variable Y") {
+		t.Fatalf("expected a local variable error, got %v", err)
+	}
+
+	expect(t, "(let ((y 1)) (var m (syntax-rules () ((_ v) v))) (m y))", "1")
+	expect(t, "(let ((y 1)) (var m (syntax-rules () ((_) 'y))) (m))", "Y")
+}
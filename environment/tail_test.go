@@ -0,0 +1,23 @@
+package environment_test
+
+import "testing"
+
+// Calls in tail position reuse the frame of the caller, so these run far
+// deeper than environment.DefaultMaxCallDepth
+
+func TestTailRecursionWithSelf(t *testing.T) {
+	expect(t, "(var count (lambda (n acc) (if (eql n 0) acc (&self (- n 1) (+ acc 1))))) (count 200000 0)", "200000")
+}
+
+func TestTailCallsInScopeAndDo(t *testing.T) {
+	expect(t, "(var down (lambda (n) (scope (var m (- n 1)) (if (< m 0) 'done (down m))))) (down 200000)", "DONE")
+	expect(t, "(var down (lambda (n) (do (if (eql n 0) 'done (down (- n 1)))))) (down 200000)", "DONE")
+}
+
+func TestMutualTailRecursion(t *testing.T) {
+	expect(t, `
+(var even? nil)
+(var odd? (lambda (n) (if (eql n 0) nil (even? (- n 1)))))
+(= even? (lambda (n) (if (eql n 0) t (odd? (- n 1)))))
+(even? 200001)`, "NIL")
+}
@@ -0,0 +1,24 @@
+package environment_test
+
+import "testing"
+
+func TestThrowAnyObject(t *testing.T) {
+	expect(t, "(try (throw :oops) (lambda (x) x))", "OOPS")
+	expect(t, "(try (throw '(1 2)) (lambda (x) (car x)))", "1")
+	expect(t, "(try (throw (dictionary '(:code 404))) (lambda (x) (elt x :code)))", "404")
+}
+
+func TestTryCatchClauses(t *testing.T) {
+	expect(t, "(try (throw (dictionary '(:code 404))) ((:string (m) m) (:dictionary (d) (elt d :code))))", "404")
+	expect(t, "(try (throw 7) (((lambda (x) (eql x 7)) (x) 'seven) (:number (x) 'number)))", "SEVEN")
+	expect(t, "(try (throw 8) (((lambda (x) (eql x 7)) (x) 'seven) (:number (x) 'number)))", "NUMBER")
+}
+
+func TestTryRethrowsUnhandledObjects(t *testing.T) {
+	expectError(t, `(try (throw "not handled") ((:number (x) x)))`, "not handled")
+	expect(t, `(try (try (throw "inner") ((:number (x) x))) ((:string (s) s)))`, `"inner"`)
+}
+
+func TestTryCatchesBuiltinErrors(t *testing.T) {
+	expect(t, "(try (elt (array 1 2 3) 5) ((:condition (c) (condition-message c))))", `"index out of bounds"`)
+}
@@ -0,0 +1,64 @@
+package environment_test
+
+import (
+	"context"
+	goErrors "errors"
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+func TestUnwindProtect(t *testing.T) {
+	expect(t, "(var cleaned nil) (list (unwind-protect 1 (= cleaned t)) cleaned)", "(1 T)")
+	expect(t, "(var cleaned nil) (block a (unwind-protect (return-from a 1) (= cleaned t))) cleaned", "T")
+	expect(t, "(var cleaned nil) (try (unwind-protect (error \"failed\") (= cleaned t)) (lambda (c) c)) cleaned", "T")
+}
+
+func TestUnwindProtectKeepsError(t *testing.T) {
+	expectError(t, "(unwind-protect (error \"protected\") (error \"cleanup\"))", "protected")
+	expectError(t, "(unwind-protect 1 (error \"cleanup\"))", "cleanup")
+	expect(t, "(block a (unwind-protect (return-from a 1) (error \"cleanup\")))", "1")
+}
+
+func TestUnwindProtectCleanupAfterCancel(t *testing.T) {
+	tests := []struct {
+		ctx    context.Context
+		budget uint64
+		cause  error
+	}{
+		{context.Background(), 100, environment.ErrStepBudgetExceeded},
+		{cancelled(), 0, context.Canceled},
+	}
+
+	for _, test := range tests {
+		env := environment.New()
+
+		_, err := load(env, "(var cleaned nil)")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = loadWithContext(env, test.ctx, test.budget, "(unwind-protect (while t 1) (= cleaned (list t)))")
+		if !goErrors.Is(err, test.cause) || !goErrors.Is(err, errors.ErrCancelled) {
+			t.Fatalf("expected %v, got %v", test.cause, err)
+		}
+
+		cleaned, err := load(env, "cleaned")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if cleaned.String() != "(T)" {
+			t.Fatalf("%v: expected cleanup to run, got %v", test.cause, cleaned)
+		}
+	}
+}
+
+// cancelled returns a context that is already cancelled
+func cancelled() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	return ctx
+}
@@ -0,0 +1,117 @@
+// Runs separate environments and a shared locked environment on multiple
+// goroutines, run with the race detector to check for data races
+// go run -race examples/concurrent/concurrent.go
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/globals/tables"
+	"github.com/almerlucke/glisp/reader"
+	"github.com/almerlucke/glisp/types"
+)
+
+const numGoroutines = 8
+
+const source = `
+(var fib (lambda (n) (if (< n 2) n (+ (fib (- n 1)) (fib (- n 2))))))
+(var id %d)
+(var key :result)
+(+ (fib 15) id)
+`
+
+func load(env *environment.Environment, src string) (types.Object, error) {
+	rd := reader.New(strings.NewReader(src), tables.DefaultReadTable, tables.DefaultDispatchTable, env)
+
+	obj, err := rd.ReadObject()
+	var result types.Object
+
+	for err == nil {
+		result, err = env.Eval(obj, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err = rd.ReadObject()
+	}
+
+	if err != io.EOF {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func separate() {
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+
+		go func(id int) {
+			defer wg.Done()
+
+			// Separate environments don't need to be locked
+			result, err := load(environment.New(), fmt.Sprintf(source, id))
+			if err != nil {
+				log.Fatalf("environment %d error %v", id, err)
+			}
+
+			if result.String() != fmt.Sprint(610+id) {
+				log.Fatalf("environment %d unexpected result %v", id, result)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func shared() {
+	env := environment.NewLocked(environment.New())
+
+	_, err := env.Load(strings.NewReader("(var counter 0)"), nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				_, err := env.Load(strings.NewReader("(= counter (+ counter 1))"), nil)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	result, err := env.Load(strings.NewReader("counter"), nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if result.String() != fmt.Sprint(numGoroutines*100) {
+		log.Fatalf("shared environment unexpected result %v", result)
+	}
+}
+
+func main() {
+	separate()
+	shared()
+
+	log.Printf("ok")
+}
@@ -0,0 +1,7 @@
+package main
+
+import "github.com/almerlucke/glisp/examples/internal/runner"
+
+func main() {
+	runner.Run("./examples/conditions/source.glisp")
+}
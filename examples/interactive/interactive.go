@@ -11,6 +11,7 @@ import (
 	"github.com/almerlucke/glisp/globals/tables"
 	"github.com/almerlucke/glisp/reader"
 	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/errors"
 )
 
 func main() {
@@ -29,7 +30,7 @@ func main() {
 		for err == nil {
 			result, err = env.Eval(obj, nil)
 			if err != nil {
-				fmt.Printf("<! %v >\n", err)
+				fmt.Printf("<! %v >\n", errors.From(err).StackTrace())
 			}
 
 			obj, err = rd.ReadObject()
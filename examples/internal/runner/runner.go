@@ -0,0 +1,37 @@
+// Package runner loads a glisp source file in a new environment, it is shared
+// by the example programs
+package runner
+
+import (
+	"bufio"
+	"log"
+	"os"
+
+	"github.com/almerlucke/glisp/builtin"
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/globals/tables"
+	"github.com/almerlucke/glisp/reader"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+// Run evaluates all objects in the file at path and logs the result of the
+// last evaluation, it exits with a stack trace if the evaluation fails
+func Run(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatal("Can't open file")
+	}
+
+	defer f.Close()
+
+	env := environment.New()
+	rd := reader.New(bufio.NewReader(f), tables.DefaultReadTable, tables.DefaultDispatchTable, env)
+	rd.SetFile(path)
+
+	result, err := builtin.LoadReader(rd, env, nil)
+	if err != nil {
+		log.Fatalf("eval error %v\n", errors.From(err).StackTrace())
+	}
+
+	log.Printf("%v\n", result)
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/almerlucke/glisp/types/symbols"
 )
 
+// The system symbols are shared by all environments and must never be
+// modified, bindings for these symbols are kept by each environment
+
 // DotSymbol is used for dotted lists in the reader
 var DotSymbol = &symbols.Symbol{
 	Name:     ".",
@@ -64,13 +67,64 @@ var SpliceSymbol = &symbols.Symbol{
 	Interned: true,
 }
 
-// AndRestSymbol is used to bind extra arguments in lambda function calls
+// AndRestSymbol names the parameter for the extra arguments in a lambda list
 var AndRestSymbol = &symbols.Symbol{
 	Name:     "&REST",
 	Reserved: true,
 	Interned: true,
 }
 
+// AndOptionalSymbol starts the optional parameters of a lambda list
+var AndOptionalSymbol = &symbols.Symbol{
+	Name:     "&OPTIONAL",
+	Reserved: true,
+	Interned: true,
+}
+
+// AndKeySymbol starts the keyword parameters of a lambda list
+var AndKeySymbol = &symbols.Symbol{
+	Name:     "&KEY",
+	Reserved: true,
+	Interned: true,
+}
+
+// AndAllowOtherKeysSymbol allows unknown keyword arguments in calls to a
+// function with keyword parameters
+var AndAllowOtherKeysSymbol = &symbols.Symbol{
+	Name:     "&ALLOW-OTHER-KEYS",
+	Reserved: true,
+	Interned: true,
+}
+
+// AndBodySymbol is the same as &REST in a macro or destructuring lambda list
+var AndBodySymbol = &symbols.Symbol{
+	Name:     "&BODY",
+	Reserved: true,
+	Interned: true,
+}
+
+// AndWholeSymbol binds the whole list matched by a macro or destructuring
+// lambda list, for a macro this is the macro call form
+var AndWholeSymbol = &symbols.Symbol{
+	Name:     "&WHOLE",
+	Reserved: true,
+	Interned: true,
+}
+
+// AndEnvironmentSymbol binds the environment a macro is expanded in
+var AndEnvironmentSymbol = &symbols.Symbol{
+	Name:     "&ENVIRONMENT",
+	Reserved: true,
+	Interned: true,
+}
+
+// OtherwiseSymbol marks the default clause of CASE and TYPECASE
+var OtherwiseSymbol = &symbols.Symbol{
+	Name:     "OTHERWISE",
+	Reserved: true,
+	Interned: true,
+}
+
 // SelfSymbol is used to bind the lambda function inside its own body,
 // to allow for recursion with anonymous functions
 var SelfSymbol = &symbols.Symbol{
@@ -6,13 +6,18 @@ import (
 	"github.com/almerlucke/glisp/reader/macros/dispatch"
 )
 
-// DefaultReadTable contains the default reader characters and syntax types
-var DefaultReadTable = generateDefaultReadTable()
+// DefaultReadTable contains the default reader characters and syntax types,
+// it is shared by all readers using it so it must not be modified, use
+// NewReadTable to get a table that can be changed
+var DefaultReadTable = NewReadTable()
 
-// DefaultDispatchTable contains the default reader dispatch table
-var DefaultDispatchTable = generateDefaultDispatchTable()
+// DefaultDispatchTable contains the default reader dispatch table, it is
+// shared by all readers using it so it must not be modified, use
+// NewDispatchTable to get a table that can be changed
+var DefaultDispatchTable = NewDispatchTable()
 
-func generateDefaultDispatchTable() reader.DispatchTable {
+// NewDispatchTable returns a new copy of the default dispatch table
+func NewDispatchTable() reader.DispatchTable {
 	table := map[rune]reader.DispatchMacroFunction{
 		'|':  dispatch.CommentDispatch,
 		'\\': dispatch.CharacterDispatch,
@@ -21,8 +26,8 @@ func generateDefaultDispatchTable() reader.DispatchTable {
 	return table
 }
 
-// GenerateDefaultReadTable returns the default read table
-func generateDefaultReadTable() reader.ReadTable {
+// NewReadTable returns a new copy of the default read table
+func NewReadTable() reader.ReadTable {
 	table := map[rune]*reader.Character{
 		reader.Backspace: &reader.Character{
 			SyntaxType: reader.Constituent,
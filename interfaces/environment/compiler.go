@@ -0,0 +1,62 @@
+package environment
+
+import (
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// Closure is the result of compiling a form, it can be run repeatedly
+// without analyzing the form again
+type Closure func(Environment, interface{}) (types.Object, error)
+
+// Reference is a variable resolved at compile time
+type Reference interface {
+	// Get the bound object, an error is returned if the variable is unbound
+	Get(env Environment) (types.Object, error)
+
+	// Bind an object to the variable in the scope it was defined in
+	Bind(env Environment, obj types.Object)
+
+	// Set assigns an object to an already bound variable
+	Set(env Environment, obj types.Object) error
+}
+
+// Compiler compiles forms into closures, special forms are resolved once
+// at compile time instead of on every evaluation. Variables are resolved to
+// a (depth, slot) pair in the layout of the compiler
+type Compiler interface {
+	Environment() Environment
+
+	// Layout of the scope the compiler compiles for, nil for the global scope
+	Layout() *scope.Layout
+
+	// Position of the innermost form being compiled that has a position,
+	// nil if unknown
+	Position() *cons.Position
+
+	// NewScope returns a compiler for a new scope nested in this scope, the
+	// compiled closures must be run with a scope for the new layout pushed
+	NewScope() Compiler
+
+	// Define a variable in the scope of the compiler
+	Define(sym *symbols.Symbol) Reference
+
+	// Resolve a variable visible from the scope of the compiler
+	Resolve(sym *symbols.Symbol) Reference
+
+	// Compile compiles a form, the closure never returns a pending tail call
+	Compile(obj types.Object) Closure
+
+	// CompileTail compiles a form in tail position, the closure can return
+	// a pending tail call which must be handled by the caller
+	CompileTail(obj types.Object) Closure
+
+	// CompileArgs compiles each form of a list separately
+	CompileArgs(args *cons.Cons) []Closure
+
+	// CompileBody compiles a sequence of forms, the last form is in tail
+	// position, the closure returns NIL for an empty body
+	CompileBody(body *cons.Cons) Closure
+}
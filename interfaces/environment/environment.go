@@ -21,16 +21,18 @@ type Environment interface {
 
 	FindInternedSymbolInNamespace(name string, ns string) *symbols.Symbol
 
-	CurrentScope() scope.Scope
+	CurrentScope() *scope.Scope
 
-	PopScope() scope.Scope
+	PopScope() *scope.Scope
 
-	PushScope(scope scope.Scope) scope.Scope
+	PushScope(scope *scope.Scope) *scope.Scope
 
-	CaptureScope() scope.Scope
+	CaptureScope() *scope.Scope
 
 	AddGlobalBinding(sym *symbols.Symbol, obj types.Object)
 
+	GetGlobalBinding(sym *symbols.Symbol) types.Object
+
 	AddBinding(sym *symbols.Symbol, obj types.Object)
 
 	GetBinding(sym *symbols.Symbol) types.Object
@@ -49,6 +51,16 @@ type Environment interface {
 
 	Eval(obj types.Object, context interface{}) (types.Object, error)
 
+	EvalTail(obj types.Object, context interface{}) (types.Object, error)
+
+	NewCompiler() Compiler
+
+	Step() error
+
+	SuspendSteps()
+
+	ResumeSteps()
+
 	Context() map[string]interface{}
 
 	PushDepthContext(string)
@@ -56,4 +68,8 @@ type Environment interface {
 	PopDepthContext(string)
 
 	HasDepthContext(string) bool
+
+	DepthContext(string) uint64
+
+	MaxCallDepth() uint64
 }
@@ -0,0 +1,43 @@
+package function
+
+import (
+	"github.com/almerlucke/glisp/types"
+)
+
+// ExitPoint is a point in the evaluation that can be exited non-locally,
+// exit points are compared by identity. Kind and Tag describe the exit
+// point to the forms looking for it
+type ExitPoint struct {
+	Kind string
+	Tag  types.Object
+}
+
+// Exit unwinds the stack to an exit point when passed to panic, the form
+// that established the exit point recovers it and returns Object
+type Exit struct {
+	Point  *ExitPoint
+	Object types.Object
+}
+
+// ReturnPoint is the exit point of the innermost lambda or macro call
+var ReturnPoint = &ExitPoint{Kind: "RETURN"}
+
+// ExitTo unwinds the stack to an exit point with obj as result
+func ExitTo(point *ExitPoint, obj types.Object) {
+	panic(&Exit{
+		Point:  point,
+		Object: obj,
+	})
+}
+
+// Landed checks if a value recovered from a panic is an exit to point and
+// returns the object of the exit, any other value continues to panic
+func Landed(r interface{}, point *ExitPoint) types.Object {
+	exit, ok := r.(*Exit)
+	if !ok || exit.Point != point {
+		// Continue to panic
+		panic(r)
+	}
+
+	return exit.Object
+}
@@ -1,25 +1,62 @@
 package function
 
 import (
+	"fmt"
+
 	"github.com/almerlucke/glisp/interfaces/environment"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
 )
 
-// ReturnContext can be passed as context to eval, the RETURN buildin function
-// can put an object in this context before panicking
-type ReturnContext struct {
-	Object types.Object
+// TailCall is returned by EvalTail instead of calling a lambda function in
+// tail position, the caller is responsible for evaluating the call so
+// recursive lambda functions can run without growing the stack
+type TailCall struct {
+	Function Function
+	Args     *cons.Cons
+}
+
+// Type of TailCall
+func (tc *TailCall) Type() types.Type {
+	return types.Function
+}
+
+// String for Stringer
+func (tc *TailCall) String() string {
+	return fmt.Sprintf("tail-call(%v)", tc.Function)
+}
+
+// Eql obj
+func (tc *TailCall) Eql(obj types.Object) bool {
+	return tc == obj
 }
 
-// Function interface
+// Equal obj
+func (tc *TailCall) Equal(obj types.Object) bool {
+	return tc == obj
+}
+
+// Variadic is returned by MaxArgs for functions accepting any number of
+// arguments following the minimum number of arguments
+const Variadic = -1
+
+// Function interface, MinArgs and MaxArgs give the number of arguments
+// the function accepts
 type Function interface {
 	types.Object
-	NumArgs() int
+	MinArgs() int
+	MaxArgs() int
 	EvalArgs() bool
 	Eval(*cons.Cons, environment.Environment, interface{}) (types.Object, error)
 }
 
+// Compilable is implemented by special forms, instead of evaluating the
+// unevaluated arguments on every call they are compiled once into a closure
+type Compilable interface {
+	Function
+	Compile(*cons.Cons, environment.Compiler) (environment.Closure, error)
+}
+
 // Assignable allows for a function to be used with = assign, the value to Assign
 // is passed as second arg to Assign, Assign should further be equal to Eval
 type Assignable interface {
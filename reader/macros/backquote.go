@@ -1,7 +1,6 @@
 package macros
 
 import (
-	"errors"
 	"io"
 
 	"github.com/almerlucke/glisp/globals/symbols"
@@ -35,7 +34,7 @@ func BackquoteMacro(rd reader.Reader) (types.Object, error) {
 		obj, err = rd.ReadObject()
 		if err != nil {
 			if err == io.EOF {
-				return nil, errors.New("end of stream before end of backquote")
+				return nil, rd.Error("end of stream before end of backquote")
 			}
 			return nil, err
 		}
@@ -1,7 +1,6 @@
 package macros
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -21,7 +20,7 @@ func DispatchMacro(rd reader.Reader) (types.Object, error) {
 
 	if err != nil {
 		if err == io.EOF {
-			return nil, errors.New("end of stream reached before end of dispatch macro")
+			return nil, rd.Error("end of stream reached before end of dispatch macro")
 		}
 
 		return nil, err
@@ -32,7 +31,7 @@ func DispatchMacro(rd reader.Reader) (types.Object, error) {
 	r, c, err := rd.ReadChar()
 	if err != nil {
 		if err == io.EOF {
-			return nil, errors.New("end of stream reached before end of dispatch macro")
+			return nil, rd.Error("end of stream reached before end of dispatch macro")
 		}
 
 		return nil, err
@@ -40,7 +39,7 @@ func DispatchMacro(rd reader.Reader) (types.Object, error) {
 
 	macro := rd.DispatchMacroForCharacter(c)
 	if macro == nil {
-		return nil, fmt.Errorf("undefined dispatch macro character %c", r)
+		return nil, rd.Error(fmt.Sprintf("undefined dispatch macro character %c", r))
 	}
 
 	return macro(uint64(arg), rd)
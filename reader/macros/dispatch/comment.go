@@ -1,16 +1,15 @@
 package dispatch
 
 import (
-	"errors"
 	"io"
 
 	"github.com/almerlucke/glisp/interfaces/reader"
 	"github.com/almerlucke/glisp/types"
 )
 
-func commentErr(err error) error {
+func commentErr(err error, rd reader.Reader) error {
 	if err == io.EOF {
-		return errors.New("end of stream reached before end of comment")
+		return rd.Error("end of stream reached before end of comment")
 	}
 
 	return err
@@ -23,13 +22,13 @@ func CommentDispatch(arg uint64, rd reader.Reader) (types.Object, error) {
 	for {
 		r, _, err := rd.ReadChar()
 		if err != nil {
-			return nil, commentErr(err)
+			return nil, commentErr(err, rd)
 		}
 
 		if r == '#' {
 			r, _, err = rd.ReadChar()
 			if err != nil {
-				return nil, commentErr(err)
+				return nil, commentErr(err, rd)
 			}
 
 			if r == '|' {
@@ -38,7 +37,7 @@ func CommentDispatch(arg uint64, rd reader.Reader) (types.Object, error) {
 		} else if r == '|' {
 			r, _, err = rd.ReadChar()
 			if err != nil {
-				return nil, commentErr(err)
+				return nil, commentErr(err, rd)
 			}
 
 			if r == '#' {
@@ -1,7 +1,6 @@
 package macros
 
 import (
-	"errors"
 	"io"
 
 	"github.com/almerlucke/glisp/globals/symbols"
@@ -35,7 +34,7 @@ func OpenParenthesisMacro(rd reader.Reader) (types.Object, error) {
 		obj, err := rd.ReadObject()
 		if err != nil {
 			if err == io.EOF {
-				return nil, errors.New("unmatched parenthesis")
+				return nil, rd.Error("unmatched parenthesis")
 			}
 
 			return nil, err
@@ -43,7 +42,7 @@ func OpenParenthesisMacro(rd reader.Reader) (types.Object, error) {
 
 		if obj == symbols.CloseParenthesisSymbol {
 			if dotFound && dottedObjCnt != 1 {
-				return nil, errors.New("expected one object after dot")
+				return nil, rd.Error("expected one object after dot")
 			}
 
 			break
@@ -54,7 +53,7 @@ func OpenParenthesisMacro(rd reader.Reader) (types.Object, error) {
 		} else if obj != nil {
 			if dotFound {
 				if builder.Tail == nil {
-					return nil, errors.New("expected at least one object before dot")
+					return nil, rd.Error("expected at least one object before dot")
 				}
 				dottedObjCnt++
 				builder.Tail.Cdr = obj
@@ -80,7 +79,7 @@ func OpenParenthesisMacro(rd reader.Reader) (types.Object, error) {
 func CloseParenthesisMacro(rd reader.Reader) (types.Object, error) {
 	ctx, ok := rd.Context()["listContext"]
 	if !ok || ctx.(*listContext).Depth == 0 {
-		return nil, errors.New("unmatched parenthesis")
+		return nil, rd.Error("unmatched parenthesis")
 	}
 
 	return symbols.CloseParenthesisSymbol, nil
@@ -1,7 +1,6 @@
 package macros
 
 import (
-	"errors"
 	"io"
 
 	"github.com/almerlucke/glisp/globals/symbols"
@@ -19,7 +18,7 @@ func QuoteMacro(rd reader.Reader) (types.Object, error) {
 		obj, err = rd.ReadObject()
 		if err != nil {
 			if err == io.EOF {
-				return nil, errors.New("end of stream reached before end of quote")
+				return nil, rd.Error("end of stream reached before end of quote")
 			}
 			return nil, err
 		}
@@ -2,7 +2,6 @@ package macros
 
 import (
 	"container/list"
-	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -49,7 +48,7 @@ func unicodeEscape(n int, escapeChar rune, rd reader.Reader) ([]rune, error) {
 	}
 
 	if len(rs) != n {
-		return nil, errors.New("unicode char literal expected %d ASCII chars")
+		return nil, rd.Error(fmt.Sprintf("unicode char literal expected %d ASCII chars", n))
 	}
 
 	str := fmt.Sprintf("'\\%c%s'", escapeChar, string(rs))
@@ -118,7 +117,7 @@ func StringMacro(rd reader.Reader) (types.Object, error) {
 		if c == '"' {
 			break
 		} else if rd.IsNewline(c) {
-			return nil, errors.New("multiline string not allowed")
+			return nil, rd.Error("multiline string not allowed")
 		} else if c == '\\' {
 			s, err := escapeSequence(rd)
 			if err != nil {
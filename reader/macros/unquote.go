@@ -1,7 +1,6 @@
 package macros
 
 import (
-	"errors"
 	"io"
 
 	globals "github.com/almerlucke/glisp/globals/symbols"
@@ -16,7 +15,7 @@ import (
 func UnquoteMacro(rd reader.Reader) (types.Object, error) {
 	ctx, ok := rd.Context()["backquoteContext"]
 	if !ok || ctx.(*BackquoteContext).Depth == 0 {
-		return nil, errors.New("unquote and splice can only be used in a backquote form")
+		return nil, rd.Error("unquote and splice can only be used in a backquote form")
 	}
 
 	splice := false
@@ -24,7 +23,7 @@ func UnquoteMacro(rd reader.Reader) (types.Object, error) {
 	r, _, err := rd.ReadChar()
 	if err != nil {
 		if err == io.EOF {
-			return nil, errors.New("end of stream reached before end of unquote")
+			return nil, rd.Error("end of stream reached before end of unquote")
 		}
 
 		return nil, err
@@ -47,10 +46,10 @@ func UnquoteMacro(rd reader.Reader) (types.Object, error) {
 		if err != nil {
 			if err == io.EOF {
 				if splice {
-					return nil, errors.New("end of stream reached before end of splice")
+					return nil, rd.Error("end of stream reached before end of splice")
 				}
 
-				return nil, errors.New("end of stream reached before end of unquote")
+				return nil, rd.Error("end of stream reached before end of unquote")
 			}
 
 			return nil, err
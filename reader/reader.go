@@ -2,7 +2,7 @@ package reader
 
 import (
 	"container/list"
-	"errors"
+	goErrors "errors"
 	"fmt"
 	"io"
 	"reflect"
@@ -14,6 +14,8 @@ import (
 	"github.com/almerlucke/glisp/interfaces/reader"
 	"github.com/almerlucke/glisp/reader/utils"
 	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
 	"github.com/almerlucke/glisp/types/numbers"
 	"github.com/almerlucke/glisp/types/symbols"
 )
@@ -23,8 +25,11 @@ type Reader struct {
 	scanner        io.RuneScanner
 	readTable      reader.ReadTable
 	dispatchTable  reader.DispatchTable
+	file           string
 	lineCount      int
 	charCount      int
+	lastCharCount  int
+	lastNewline    bool
 	env            environment.Environment
 	context        map[string]interface{}
 	listDepth      int
@@ -36,22 +41,44 @@ func (rd *Reader) Context() map[string]interface{} {
 	return rd.context
 }
 
+// SetFile sets the name of the file that is read, the name is used in
+// positions and errors
+func (rd *Reader) SetFile(file string) {
+	rd.file = file
+}
+
+// Position returns the current position of the reader
+func (rd *Reader) Position() *cons.Position {
+	return &cons.Position{
+		File:   rd.file,
+		Line:   rd.lineCount + 1,
+		Column: rd.charCount + 1,
+	}
+}
+
 // Error specific for the reader
 func (rd *Reader) Error(msg string) error {
-	return fmt.Errorf("error on line %d, char %d: %s",
-		rd.lineCount+1,
-		rd.charCount+1,
-		msg,
-	)
+	return &errors.Error{
+		Kind:    errors.ReaderError,
+		Message: msg,
+		Pos:     rd.Position(),
+	}
 }
 
-// ErrorWithError specific for the reader
+// ErrorWithError specific for the reader, end of stream and glisp errors
+// are returned as is
 func (rd *Reader) ErrorWithError(err error) error {
-	if err == io.EOF {
+	var e *errors.Error
+	if err == io.EOF || goErrors.As(err, &e) {
 		return err
 	}
 
-	return rd.Error(err.Error())
+	return &errors.Error{
+		Kind:    errors.ReaderError,
+		Message: err.Error(),
+		Pos:     rd.Position(),
+		Err:     err,
+	}
 }
 
 // New creates a new reader
@@ -73,6 +100,8 @@ func (rd *Reader) ReadChar() (rune, *reader.Character, error) {
 		return c, nil, err
 	}
 
+	rd.lastNewline = false
+
 	if c == reader.Newline {
 		rd.newLine()
 	} else if c == reader.Return {
@@ -103,13 +132,23 @@ func (rd *Reader) ReadChar() (rune, *reader.Character, error) {
 }
 
 func (rd *Reader) newLine() {
+	rd.lastCharCount = rd.charCount
+	rd.lastNewline = true
 	rd.lineCount++
 	rd.charCount = 0
 }
 
 // UnreadChar unreads a single character from the stream
 func (rd *Reader) UnreadChar() error {
-	rd.charCount--
+	if rd.lastNewline {
+		// Go back to the end of the previous line
+		rd.lastNewline = false
+		rd.lineCount--
+		rd.charCount = rd.lastCharCount
+	} else {
+		rd.charCount--
+	}
+
 	return rd.scanner.UnreadRune()
 }
 
@@ -213,7 +252,7 @@ func (rd *Reader) tokenToObject(token string) (types.Object, error) {
 			}
 
 			if sym == nil {
-				return nil, fmt.Errorf("unknown symbol %v in namespace %v", name, ns)
+				return nil, rd.Error(fmt.Sprintf("unknown symbol %v in namespace %v", name, ns))
 			}
 		} else {
 			sym = rd.env.InternSymbol(token)
@@ -239,7 +278,7 @@ func (rd *Reader) ParseToken(extended bool) (string, error) {
 		if err != nil {
 			if err == io.EOF {
 				if singleEscapeActive || multipleEscapeActive {
-					return "", errors.New("end of stream reached before end of escape")
+					return "", rd.Error("end of stream reached before end of escape")
 				}
 
 				break
@@ -249,7 +288,7 @@ func (rd *Reader) ParseToken(extended bool) (string, error) {
 		}
 
 		if ci == nil {
-			return "", fmt.Errorf("illegal character %c found", c)
+			return "", rd.Error(fmt.Sprintf("illegal character %c found", c))
 		}
 
 		if singleEscapeActive {
@@ -295,21 +334,34 @@ func (rd *Reader) DispatchMacroForCharacter(c *reader.Character) reader.Dispatch
 	return rd.dispatchTable[unicode.ToLower(c.Char)]
 }
 
-// ReadObject reads an object from the stream
+// ReadObject reads an object from the stream, errors are returned as reader
+// errors with a position except for the end of the stream
 func (rd *Reader) ReadObject() (types.Object, error) {
+	obj, err := rd.readObject()
+	if err != nil {
+		return nil, rd.ErrorWithError(err)
+	}
+
+	return obj, nil
+}
+
+// readObject reads an object from the stream
+func (rd *Reader) readObject() (types.Object, error) {
 	// First skip whitespace
 	err := rd.skipWhitespace()
 	if err != nil {
 		return nil, err
 	}
 
+	pos := rd.Position()
+
 	c, ci, err := rd.ReadChar()
 	if err != nil {
 		return nil, err
 	}
 
 	if ci == nil {
-		return nil, fmt.Errorf("illegal character %c found", c)
+		return nil, rd.Error(fmt.Sprintf("illegal character %c found", c))
 	}
 
 	var obj types.Object
@@ -350,8 +402,14 @@ func (rd *Reader) ReadObject() (types.Object, error) {
 			if obj == nil {
 				return rd.ReadObject()
 			}
+
+			// Remember where the list starts, nested lists already have
+			// their own position
+			if list, ok := obj.(*cons.Cons); ok && list.Pos == nil {
+				list.Pos = pos
+			}
 		} else {
-			return nil, fmt.Errorf("no macro function attached to macro char %c", c)
+			return nil, rd.Error(fmt.Sprintf("no macro function attached to macro char %c", c))
 		}
 	}
 
@@ -0,0 +1,55 @@
+package reader_test
+
+import (
+	goErrors "errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/almerlucke/glisp/environment"
+	"github.com/almerlucke/glisp/globals/tables"
+	"github.com/almerlucke/glisp/reader"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+func TestReadErrorsAreReaderErrors(t *testing.T) {
+	sources := map[string]string{
+		"(1 2":          "unmatched parenthesis",
+		"\n  )":         "unmatched parenthesis",
+		"(1 . )":        "expected one object after dot",
+		"\"a\nb\"":      "multiline string not allowed",
+		"#|":            "end of stream reached before end of comment",
+		"#<":            "undefined dispatch macro character <",
+		"glisp::nosuch": "unknown symbol NOSUCH in namespace GLISP",
+		",x":            "unquote and splice can only be used in a backquote form",
+	}
+
+	for source, message := range sources {
+		rd := reader.New(strings.NewReader(source), tables.DefaultReadTable, tables.DefaultDispatchTable, environment.New())
+
+		_, err := rd.ReadObject()
+
+		var e *errors.Error
+		if !goErrors.As(err, &e) {
+			t.Errorf("%q expected a glisp error, got %v", source, err)
+			continue
+		}
+
+		if e.Kind != errors.ReaderError || e.Pos == nil {
+			t.Errorf("%q expected a reader error with position, got %v %v", source, e.Kind, e.Pos)
+		}
+
+		if e.Message != message {
+			t.Errorf("%q expected message %q, got %q", source, message, e.Message)
+		}
+	}
+}
+
+func TestReadEndOfStream(t *testing.T) {
+	rd := reader.New(strings.NewReader("  "), tables.DefaultReadTable, tables.DefaultDispatchTable, environment.New())
+
+	_, err := rd.ReadObject()
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
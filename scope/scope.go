@@ -5,5 +5,152 @@ import (
 	"github.com/almerlucke/glisp/types/symbols"
 )
 
-// Scope holds the bindings of a symbol to an object
-type Scope map[*symbols.Symbol]types.Object
+// Layout describes the variables of a scope at compile time, every variable
+// gets a fixed slot so it can be accessed by (depth, slot) at runtime
+type Layout struct {
+	Parent  *Layout
+	Symbols []*symbols.Symbol
+	slots   map[*symbols.Symbol]int
+	labels  []*label
+}
+
+// label names an exit point established by a form compiled for a layout,
+// the exit point is identified at runtime by the object bound to sym
+type label struct {
+	kind string
+	tag  types.Object
+	sym  *symbols.Symbol
+}
+
+// NewLayout creates a new layout nested in parent, parent is nil for a
+// layout directly under the global scope
+func NewLayout(parent *Layout) *Layout {
+	return &Layout{
+		Parent: parent,
+		slots:  map[*symbols.Symbol]int{},
+	}
+}
+
+// Define returns the slot of a symbol, a new slot is added if the symbol
+// is not yet defined in this layout
+func (l *Layout) Define(sym *symbols.Symbol) int {
+	slot, ok := l.slots[sym]
+	if ok {
+		return slot
+	}
+
+	slot = len(l.Symbols)
+	l.slots[sym] = slot
+	l.Symbols = append(l.Symbols, sym)
+
+	return slot
+}
+
+// Slot returns the slot of a symbol defined in this layout
+func (l *Layout) Slot(sym *symbols.Symbol) (int, bool) {
+	slot, ok := l.slots[sym]
+	return slot, ok
+}
+
+// Resolve finds the depth and slot of a symbol, depth is the number of
+// parents to walk up from this layout
+func (l *Layout) Resolve(sym *symbols.Symbol) (int, int, bool) {
+	depth := 0
+
+	for e := l; e != nil; e = e.Parent {
+		slot, ok := e.slots[sym]
+		if ok {
+			return depth, slot, true
+		}
+
+		depth++
+	}
+
+	return 0, 0, false
+}
+
+// DefineLabel defines a variable for an exit point of kind named by tag, BLOCK
+// names and TAGBODY tags are labels so they are resolved lexically
+func (l *Layout) DefineLabel(kind string, tag types.Object) *symbols.Symbol {
+	sym := &symbols.Symbol{Name: kind}
+
+	l.Define(sym)
+	l.labels = append(l.labels, &label{kind: kind, tag: tag, sym: sym})
+
+	return sym
+}
+
+// ResolveLabel finds the variable of the innermost exit point of kind named
+// by tag
+func (l *Layout) ResolveLabel(kind string, tag types.Object) (*symbols.Symbol, bool) {
+	for e := l; e != nil; e = e.Parent {
+		for i := len(e.labels) - 1; i >= 0; i-- {
+			if e.labels[i].kind == kind && e.labels[i].tag.Eql(tag) {
+				return e.labels[i].sym, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Scope is a runtime frame for a layout, it holds the bindings of the
+// variables in slot order and links to the lexically enclosing scope
+type Scope struct {
+	Layout *Layout
+	Parent *Scope
+	Values []types.Object
+}
+
+// New creates a new scope for layout nested in parent
+func New(layout *Layout, parent *Scope) *Scope {
+	return &Scope{
+		Layout: layout,
+		Parent: parent,
+		Values: make([]types.Object, len(layout.Symbols)),
+	}
+}
+
+// Get returns the object bound to slot or nil if unbound, the layout can
+// grow after the scope was created
+func (s *Scope) Get(slot int) types.Object {
+	if slot < len(s.Values) {
+		return s.Values[slot]
+	}
+
+	return nil
+}
+
+// Set binds an object to slot
+func (s *Scope) Set(slot int, obj types.Object) {
+	if slot >= len(s.Values) {
+		values := make([]types.Object, len(s.Layout.Symbols))
+		copy(values, s.Values)
+		s.Values = values
+	}
+
+	s.Values[slot] = obj
+}
+
+// Up walks up depth parents
+func (s *Scope) Up(depth int) *Scope {
+	for ; depth > 0; depth-- {
+		s = s.Parent
+	}
+
+	return s
+}
+
+// Lookup finds the scope and slot holding a binding for sym, it walks the
+// scope chain by symbol and is used when a variable could not be resolved at
+// compile time
+func (s *Scope) Lookup(sym *symbols.Symbol) (*Scope, int, bool) {
+	for e := s; e != nil; e = e.Parent {
+		slot, ok := e.Layout.slots[sym]
+		if ok && e.Get(slot) != nil {
+			return e, slot, true
+		}
+	}
+
+	return nil, 0, false
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/almerlucke/glisp/types/numbers"
 )
 
-// Cons is the main list structure
+// Cons is the main list structure, Pos is only set for lists read by the
+// reader. Pos is not hashed so equal lists are the same dictionary key
 type Cons struct {
 	Car types.Object
 	Cdr types.Object
+	Pos *Position `hash:"ignore"`
 }
 
 // Type Cons for Object interface
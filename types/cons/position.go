@@ -0,0 +1,21 @@
+package cons
+
+import (
+	"fmt"
+)
+
+// Position is the location in the source of a list read by the reader
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String for stringer interface
+func (pos *Position) String() string {
+	if pos.File == "" {
+		return fmt.Sprintf("line %d, column %d", pos.Line, pos.Column)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", pos.File, pos.Line, pos.Column)
+}
@@ -0,0 +1,44 @@
+package environments
+
+import (
+	"fmt"
+
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+)
+
+// Environment is bound to the &ENVIRONMENT parameter of a macro, it holds
+// the environment and the lexical scope of the macro call
+type Environment struct {
+	Env   environment.Environment
+	Scope *scope.Scope
+}
+
+// New creates a new environment object for the current scope of env
+func New(env environment.Environment) *Environment {
+	return &Environment{
+		Env:   env,
+		Scope: env.CurrentScope(),
+	}
+}
+
+// Type Environment
+func (e *Environment) Type() types.Type {
+	return types.Environment
+}
+
+// String for Stringer
+func (e *Environment) String() string {
+	return fmt.Sprintf("environment(%p)", e)
+}
+
+// Eql obj
+func (e *Environment) Eql(obj types.Object) bool {
+	return e == obj
+}
+
+// Equal obj
+func (e *Environment) Equal(obj types.Object) bool {
+	return e == obj
+}
@@ -0,0 +1,263 @@
+package errors
+
+import (
+	"bytes"
+	goErrors "errors"
+	"fmt"
+
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+)
+
+// Frame is a function call on the stack of an error
+type Frame struct {
+	Name string
+	Args *cons.Cons
+	Pos  *cons.Position
+}
+
+// String for stringer interface
+func (frame *Frame) String() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("(")
+	buffer.WriteString(frame.Name)
+
+	if frame.Args != nil {
+		_ = frame.Args.Iter(func(obj types.Object, index interface{}) (bool, error) {
+			buffer.WriteString(" ")
+			buffer.WriteString(obj.String())
+			return false, nil
+		})
+	}
+
+	buffer.WriteString(")")
+
+	if frame.Pos != nil {
+		buffer.WriteString(fmt.Sprintf(" at %v", frame.Pos))
+	}
+
+	return buffer.String()
+}
+
+// Kind of error, it allows to distinguish errors without parsing messages
+type Kind string
+
+const (
+	// GeneralError is the kind of errors without a more specific kind
+	GeneralError Kind = "error"
+	// UnboundSymbolError is the kind of errors for unbound symbols
+	UnboundSymbolError Kind = "unbound-symbol"
+	// ArityError is the kind of errors for calls with a wrong number of
+	// arguments
+	ArityError Kind = "arity"
+	// TypeError is the kind of errors for arguments of the wrong type
+	TypeError Kind = "type"
+	// ReaderError is the kind of errors found while reading
+	ReaderError Kind = "reader"
+	// UserError is the kind of errors thrown by glisp code
+	UserError Kind = "user-thrown"
+	// WarningCondition is the kind of warnings without a more specific kind
+	WarningCondition Kind = "warning"
+	// SimpleCondition is the kind of signaled conditions without a more
+	// specific kind
+	SimpleCondition Kind = "condition"
+)
+
+// Severity of a condition
+type Severity int
+
+const (
+	// ErrorSeverity conditions stop the evaluation if they are not handled
+	ErrorSeverity Severity = iota
+	// WarningSeverity conditions are reported if they are not handled
+	WarningSeverity
+	// ConditionSeverity conditions are ignored if they are not handled
+	ConditionSeverity
+)
+
+// ErrCancelled is matched by errors that stop the evaluation of glisp code
+// from the outside or when the call depth is exceeded, these errors can't be
+// handled by glisp code
+var ErrCancelled = goErrors.New("evaluation cancelled")
+
+// Error is a glisp error, it holds the position of the form that caused the
+// error and the stack of function calls that led to it, the innermost call
+// first. Object is the object thrown by glisp code and Err the Go error
+// that caused the error, if any. An error is also the condition object
+// passed to condition handlers, Signaled is set once the handlers for the
+// condition have been invoked
+type Error struct {
+	Kind     Kind
+	Severity Severity
+	Message  string
+	Pos      *cons.Position
+	Object   types.Object
+	Stack    []*Frame
+	Err      error
+	Signaled bool
+}
+
+// New creates a new error
+func New(kind Kind, msg string) *Error {
+	return &Error{
+		Kind:    kind,
+		Message: msg,
+	}
+}
+
+// Errorf creates a new error with a formatted message
+func Errorf(kind Kind, format string, a ...interface{}) *Error {
+	return New(kind, fmt.Sprintf(format, a...))
+}
+
+// Copy returns a copy of the condition without position and stack, it is
+// used to signal a condition object again
+func (e *Error) Copy() *Error {
+	return &Error{
+		Kind:     e.Kind,
+		Severity: e.Severity,
+		Message:  e.Message,
+		Object:   e.Object,
+		Err:      e.Err,
+	}
+}
+
+// Error for error interface
+func (e *Error) Error() string {
+	if e.Pos != nil {
+		return fmt.Sprintf("%v: %v", e.Pos, e.Message)
+	}
+
+	return e.Message
+}
+
+// Unwrap returns the Go error that caused the error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Type Condition for Object interface
+func (e *Error) Type() types.Type {
+	return types.Condition
+}
+
+// String for stringer interface
+func (e *Error) String() string {
+	return fmt.Sprintf("condition(%v: %v)", e.Kind, e.Message)
+}
+
+// Eql obj
+func (e *Error) Eql(obj types.Object) bool {
+	return e == obj
+}
+
+// Equal obj
+func (e *Error) Equal(obj types.Object) bool {
+	return e == obj
+}
+
+const (
+	// traceHead and traceTail are the number of frames printed at the top
+	// and bottom of a long stack trace, the frames in between are omitted
+	traceHead = 20
+	traceTail = 10
+)
+
+// traceEntry is a frame of a stack trace repeated count times in a row
+type traceEntry struct {
+	frame string
+	count int
+}
+
+// StackTrace returns the error followed by the function calls that led to
+// it, consecutive identical calls are printed once with a repeat count and
+// the middle of a long stack is omitted
+func (e *Error) StackTrace() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(e.Error())
+
+	entries := []*traceEntry{}
+
+	for _, frame := range e.Stack {
+		str := frame.String()
+
+		n := len(entries)
+		if n > 0 && entries[n-1].frame == str {
+			entries[n-1].count++
+			continue
+		}
+
+		entries = append(entries, &traceEntry{frame: str, count: 1})
+	}
+
+	omitted := 0
+
+	for i, entry := range entries {
+		if len(entries) > traceHead+traceTail && i >= traceHead && i < len(entries)-traceTail {
+			omitted += entry.count
+
+			if i == len(entries)-traceTail-1 {
+				buffer.WriteString(fmt.Sprintf("\n    ... %d more frames", omitted))
+			}
+
+			continue
+		}
+
+		buffer.WriteString("\n    ")
+		buffer.WriteString(entry.frame)
+
+		if entry.count > 1 {
+			buffer.WriteString(fmt.Sprintf("\n    ... repeated %d times", entry.count))
+		}
+	}
+
+	return buffer.String()
+}
+
+// Find returns the glisp error in the chain of err, if there is none err is
+// wrapped in a new glisp error of kind GeneralError which is returned in
+// place of err
+func Find(err error) (error, *Error) {
+	var e *Error
+	if goErrors.As(err, &e) {
+		return err, e
+	}
+
+	e = &Error{
+		Kind:    GeneralError,
+		Message: err.Error(),
+		Err:     err,
+	}
+
+	return e, e
+}
+
+// From returns the glisp error in the chain of err, if there is none err is
+// wrapped in a new glisp error of kind GeneralError
+func From(err error) *Error {
+	_, e := Find(err)
+	return e
+}
+
+// WithPosition sets the position of a glisp error if it doesn't have
+// one yet, so the error keeps the position of the innermost form
+func WithPosition(err error, pos *cons.Position) error {
+	err, e := Find(err)
+
+	if e.Pos == nil {
+		e.Pos = pos
+	}
+
+	return err
+}
+
+// WithFrame adds a function call to the stack of a glisp error
+func WithFrame(err error, frame *Frame) error {
+	err, e := Find(err)
+
+	e.Stack = append(e.Stack, frame)
+
+	return err
+}
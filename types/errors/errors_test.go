@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStackTraceCollapsesRepeatedFrames(t *testing.T) {
+	e := New(GeneralError, "boom")
+
+	e.Stack = append(e.Stack, &Frame{Name: "INNER"})
+
+	for i := 0; i < 10000; i++ {
+		e.Stack = append(e.Stack, &Frame{Name: "LOOP"})
+	}
+
+	e.Stack = append(e.Stack, &Frame{Name: "OUTER"})
+
+	expected := "boom\n    (INNER)\n    (LOOP)\n    ... repeated 10000 times\n    (OUTER)"
+	if trace := e.StackTrace(); trace != expected {
+		t.Fatalf("expected %q, got %q", expected, trace)
+	}
+}
+
+func TestStackTraceOmitsMiddleOfLongStack(t *testing.T) {
+	e := New(GeneralError, "boom")
+
+	for i := 0; i < 10000; i++ {
+		e.Stack = append(e.Stack, &Frame{Name: fmt.Sprintf("F%d", i)})
+	}
+
+	lines := strings.Split(e.StackTrace(), "\n")
+	if len(lines) != 1+traceHead+1+traceTail {
+		t.Fatalf("expected %d lines, got %d", 1+traceHead+1+traceTail, len(lines))
+	}
+
+	if lines[1+traceHead] != fmt.Sprintf("    ... %d more frames", 10000-traceHead-traceTail) {
+		t.Fatalf("unexpected omission line %q", lines[1+traceHead])
+	}
+
+	if lines[len(lines)-1] != "    (F9999)" {
+		t.Fatalf("expected last frame F9999, got %q", lines[len(lines)-1])
+	}
+}
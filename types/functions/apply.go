@@ -0,0 +1,62 @@
+package functions
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+// plural returns the singular or plural form of argument for n
+func plural(n int) string {
+	if n == 1 {
+		return "argument"
+	}
+
+	return "arguments"
+}
+
+// Arity is implemented by functions and lambda lists
+type Arity interface {
+	MinArgs() int
+	MaxArgs() int
+}
+
+// CheckArity returns an arity error if fun does not accept n arguments, name
+// is used to refer to the function in the error message
+func CheckArity(name interface{}, fun Arity, n int) error {
+	min := fun.MinArgs()
+	max := fun.MaxArgs()
+
+	if n >= min && (max == function.Variadic || n <= max) {
+		return nil
+	}
+
+	if max == function.Variadic {
+		return errors.Errorf(errors.ArityError, "%v expected at least %d %s, got %d", name, min, plural(min), n)
+	}
+
+	if min == max {
+		return errors.Errorf(errors.ArityError, "%v expected %d %s, got %d", name, min, plural(min), n)
+	}
+
+	return errors.Errorf(errors.ArityError, "%v expected %d to %d arguments, got %d", name, min, max, n)
+}
+
+// Apply calls a function from Go with already evaluated arguments
+func Apply(fun function.Function, args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	var length int64
+	if args != nil {
+		_, length = args.Info()
+	}
+
+	err := CheckArity(Name(fun, nil), fun, int(length))
+	if err != nil {
+		return nil, err
+	}
+
+	return Nested(env, func() (types.Object, error) {
+		return fun.Eval(args, env, context)
+	})
+}
@@ -16,9 +16,9 @@ type AssignableFunction struct {
 }
 
 // NewAssignableFunction creates a new assignable function
-func NewAssignableFunction(imp BuiltinFunctionImp, assignImp AssignableFunctionImp, numArgs int, evalArgs bool) *AssignableFunction {
+func NewAssignableFunction(imp BuiltinFunctionImp, assignImp AssignableFunctionImp, minArgs int, maxArgs int, evalArgs bool) *AssignableFunction {
 	return &AssignableFunction{
-		BuiltinFunction: NewBuiltinFunction(imp, numArgs, evalArgs),
+		BuiltinFunction: NewBuiltinFunction(imp, minArgs, maxArgs, evalArgs),
 		assignImp:       assignImp,
 	}
 }
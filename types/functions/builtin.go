@@ -13,23 +13,43 @@ type BuiltinFunctionImp func(*cons.Cons, environment.Environment, interface{}) (
 
 // BuiltinFunction object
 type BuiltinFunction struct {
+	name     string
 	imp      BuiltinFunctionImp
-	numArgs  int
+	minArgs  int
+	maxArgs  int
 	evalArgs bool
 }
 
-// NewBuiltinFunction creates a new builtin function
-func NewBuiltinFunction(imp BuiltinFunctionImp, numArgs int, evalArgs bool) *BuiltinFunction {
+// NewBuiltinFunction creates a new builtin function, maxArgs is
+// function.Variadic if the function accepts any number of arguments
+func NewBuiltinFunction(imp BuiltinFunctionImp, minArgs int, maxArgs int, evalArgs bool) *BuiltinFunction {
 	return &BuiltinFunction{
 		imp:      imp,
-		numArgs:  numArgs,
+		minArgs:  minArgs,
+		maxArgs:  maxArgs,
 		evalArgs: evalArgs,
 	}
 }
 
-// NumArgs number of arguments
-func (fun *BuiltinFunction) NumArgs() int {
-	return fun.numArgs
+// Name of the symbol the function was first bound to, empty if the function
+// was never bound
+func (fun *BuiltinFunction) Name() string {
+	return fun.name
+}
+
+// SetName sets the name used for the function in error messages
+func (fun *BuiltinFunction) SetName(name string) {
+	fun.name = name
+}
+
+// MinArgs minimum number of arguments
+func (fun *BuiltinFunction) MinArgs() int {
+	return fun.minArgs
+}
+
+// MaxArgs maximum number of arguments
+func (fun *BuiltinFunction) MaxArgs() int {
+	return fun.maxArgs
 }
 
 // EvalArgs evaluate arguments before calling eval
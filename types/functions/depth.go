@@ -0,0 +1,49 @@
+package functions
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/errors"
+)
+
+// depthError is the type of ErrStackDepthExceeded
+type depthError struct{}
+
+// Error for error interface
+func (e *depthError) Error() string {
+	return "stack depth exceeded"
+}
+
+// Is matches errors.ErrCancelled, a runaway recursion stops the evaluation
+// and can't be caught and retried by glisp code
+func (e *depthError) Is(target error) bool {
+	return target == errors.ErrCancelled
+}
+
+// ErrStackDepthExceeded is returned when the nesting of function calls is
+// deeper than the maximum call depth of the environment
+var ErrStackDepthExceeded error = &depthError{}
+
+func checkCallDepth(env environment.Environment) error {
+	max := env.MaxCallDepth()
+	if max > 0 && env.DepthContext("CallDepth")+env.DepthContext("ExpansionDepth")+env.DepthContext("EvalDepth") > max {
+		return ErrStackDepthExceeded
+	}
+
+	return nil
+}
+
+// Nested calls eval as an evaluation nested in the current one, it counts
+// towards the maximum call depth like a function call. EVAL, LOAD and Apply
+// nest evaluations without calling a lambda function
+func Nested(env environment.Environment, eval func() (types.Object, error)) (types.Object, error) {
+	env.PushDepthContext("EvalDepth")
+	defer env.PopDepthContext("EvalDepth")
+
+	err := checkCallDepth(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return eval()
+}
@@ -0,0 +1,66 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// Description names and documents a function defined with DEFUN or
+// DEFMACRO
+type Description struct {
+	Name *symbols.Symbol
+	Doc  string
+}
+
+// Described is implemented by lambda and macro functions, Description
+// returns nil for anonymous functions
+type Described interface {
+	function.Function
+	Description() *Description
+	LambdaList() *LambdaList
+}
+
+// Name returns the name used for fun in error messages and stack traces.
+// Lambda and macro functions are named by their description or else by the
+// position of the form that created them, so argument errors raised while
+// binding name them the same way as errors raised at the call site. Other
+// functions are named by the symbol they are called with, call can be nil,
+// and builtin functions fall back to the symbol they were first bound to
+func Name(fun function.Function, call types.Object) string {
+	if described, ok := fun.(Described); ok {
+		if described.Description() != nil {
+			return described.Description().Name.String()
+		}
+
+		var pos *cons.Position
+		kind := "lambda"
+
+		switch f := fun.(type) {
+		case *LambdaFunction:
+			pos = f.Position()
+		case *MacroFunction:
+			pos = f.Position()
+			kind = "macro"
+		}
+
+		if pos != nil {
+			return fmt.Sprintf("<%v %v>", kind, pos)
+		}
+
+		return fun.String()
+	}
+
+	if call != nil && call.Type() == types.Symbol {
+		return call.String()
+	}
+
+	if builtin, ok := fun.(*BuiltinFunction); ok && builtin.Name() != "" {
+		return builtin.Name()
+	}
+
+	return fun.String()
+}
@@ -8,30 +8,57 @@ import (
 	"github.com/almerlucke/glisp/scope"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
-	"github.com/almerlucke/glisp/types/symbols"
-
-	globals "github.com/almerlucke/glisp/globals/symbols"
 )
 
-// LambdaFunction anonymous function
+// LambdaFunction anonymous function, the captured scope is not copied so
+// captured variables are shared with the scope the lambda was created in
 type LambdaFunction struct {
-	argList       []*symbols.Symbol
-	capturedScope scope.Scope
-	body          *cons.Cons
+	lambdaList    *LambdaList
+	description   *Description
+	layout        *scope.Layout
+	capturedScope *scope.Scope
+	body          environment.Closure
+	pos           *cons.Position
 }
 
-// NewLambdaFunction creates a new lambda function
-func NewLambdaFunction(argList []*symbols.Symbol, capturedScope scope.Scope, body *cons.Cons) *LambdaFunction {
+// NewLambdaFunction creates a new lambda function, description is nil for an
+// anonymous function
+func NewLambdaFunction(lambdaList *LambdaList, description *Description, layout *scope.Layout, capturedScope *scope.Scope, body environment.Closure, pos *cons.Position) *LambdaFunction {
 	return &LambdaFunction{
-		argList:       argList,
+		lambdaList:    lambdaList,
+		description:   description,
+		layout:        layout,
 		capturedScope: capturedScope,
 		body:          body,
+		pos:           pos,
 	}
 }
 
-// NumArgs number of arguments
-func (fun *LambdaFunction) NumArgs() int {
-	return len(fun.argList)
+// Description returns the name and docstring of the function, nil for an
+// anonymous function
+func (fun *LambdaFunction) Description() *Description {
+	return fun.description
+}
+
+// LambdaList returns the lambda list of the function
+func (fun *LambdaFunction) LambdaList() *LambdaList {
+	return fun.lambdaList
+}
+
+// Position returns the position of the lambda form that created the function,
+// nil if unknown
+func (fun *LambdaFunction) Position() *cons.Position {
+	return fun.pos
+}
+
+// MinArgs minimum number of arguments
+func (fun *LambdaFunction) MinArgs() int {
+	return fun.lambdaList.MinArgs()
+}
+
+// MaxArgs maximum number of arguments
+func (fun *LambdaFunction) MaxArgs() int {
+	return fun.lambdaList.MaxArgs()
 }
 
 // EvalArgs evaluate args
@@ -46,6 +73,10 @@ func (fun *LambdaFunction) Type() types.Type {
 
 // String for Stringer
 func (fun *LambdaFunction) String() string {
+	if fun.description != nil {
+		return fmt.Sprintf("lambda(%v)", fun.description.Name)
+	}
+
 	return fmt.Sprintf("lambda(%p)", fun)
 }
 
@@ -59,72 +90,71 @@ func (fun *LambdaFunction) Equal(obj types.Object) bool {
 	return fun == obj
 }
 
-// Eval lambda function evaluation
-func (fun *LambdaFunction) Eval(args *cons.Cons, env environment.Environment, context interface{}) (result types.Object, err error) {
-	// First push captured scope
-	env.PushScope(fun.capturedScope)
+// Bind arguments and evaluate the body, the last form of the body is
+// evaluated in tail position so the result can be a pending tail call
+func (fun *LambdaFunction) evalBody(args *cons.Cons, env environment.Environment, context interface{}) (result types.Object, err error) {
+	s := scope.New(fun.layout, fun.capturedScope)
+
+	// Bind &self symbol with the lambda function itself
+	s.Values[fun.lambdaList.Slots] = fun
+
+	// Push local scope for input arguments, the captured scope is the parent
+	// of the local scope. Default forms are evaluated in the local scope
+	env.PushScope(s)
 
-	// Push local scope for bound input arguments
-	env.PushScope(nil)
+	// Pop local scope, even when an error occurs
+	defer env.PopScope()
 
+	// Bind arguments
+	err = fun.lambdaList.Bind(Name(fun, nil), s, types.NIL, listObject(args), env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	// Last form of the body is in tail position
+	return fun.body(env, context)
+}
+
+// Eval lambda function evaluation, tail calls to other lambda functions
+// are evaluated in a loop instead of recursively
+func (fun *LambdaFunction) Eval(args *cons.Cons, env environment.Environment, context interface{}) (result types.Object, err error) {
 	// Push call
 	env.PushDepthContext("CallDepth")
 
-	// Pop both local and captured scopes, even when an error occurs
 	defer func() {
-		// Pop scopes
-		env.PopScope()
-		env.PopScope()
-
 		// Pop call
 		env.PopDepthContext("CallDepth")
 
 		if r := recover(); r != nil {
 			// Return value
-			returnContext, ok := r.(*function.ReturnContext)
-			if ok {
-				result = returnContext.Object
-			} else {
-				// Continue to panic
-				panic(r)
-			}
+			result = function.Landed(r, function.ReturnPoint)
 		}
 	}()
 
-	// Bind arguments
-	for _, sym := range fun.argList {
-		if sym.Reserved {
-			return nil, fmt.Errorf("can't bind to reserved symbol %v", sym)
-		}
-
-		env.AddBinding(sym, args.Car)
-
-		if args.Cdr.Type() == types.Cons {
-			args = args.Cdr.(*cons.Cons)
-		} else {
-			args = nil
-		}
+	err = checkCallDepth(env)
+	if err != nil {
+		return nil, err
 	}
 
-	// Add binding for &rest symbol with remaining args
-	if args != nil {
-		env.AddBinding(globals.AndRestSymbol, args)
-	} else {
-		env.AddBinding(globals.AndRestSymbol, types.NIL)
-	}
-
-	// Add binding for &self symbol with the lambda function itself
-	env.AddBinding(globals.SelfSymbol, fun)
-
-	if fun.body != nil {
-		err = fun.body.Iter(func(obj types.Object, index interface{}) (bool, error) {
-			result, err = env.Eval(obj, context)
-			return false, err
-		})
-
+	for {
+		result, err = fun.evalBody(args, env, context)
 		if err != nil {
 			return nil, err
 		}
+
+		tailCall, ok := result.(*function.TailCall)
+		if !ok {
+			break
+		}
+
+		next, ok := tailCall.Function.(*LambdaFunction)
+		if !ok {
+			return tailCall.Function.Eval(tailCall.Args, env, context)
+		}
+
+		// Reuse this call for the function in tail position
+		fun = next
+		args = tailCall.Args
 	}
 
 	// Return the result
@@ -0,0 +1,246 @@
+package functions
+
+import (
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/interfaces/function"
+	"github.com/almerlucke/glisp/scope"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+	"github.com/almerlucke/glisp/types/errors"
+	"github.com/almerlucke/glisp/types/symbols"
+)
+
+// Parameter of a lambda list, Default is evaluated in the scope of the
+// function when an optional or key argument is not supplied, it is nil
+// for a NIL default. Supplied is the optional supplied-p variable. In a
+// macro or destructuring lambda list the argument is destructured by
+// Pattern instead of bound to Symbol if Pattern is not nil
+type Parameter struct {
+	Symbol       *symbols.Symbol
+	Keyword      *symbols.Symbol
+	Default      environment.Closure
+	Supplied     *symbols.Symbol
+	Pattern      *LambdaList
+	Slot         int
+	SuppliedSlot int
+}
+
+// LambdaList describes the parameters of a lambda or macro, Slots is the
+// number of slots used by the parameters. Whole and Environment are only
+// used by macro and destructuring lambda lists, Form is the source of the
+// lambda list and names nested lambda lists in error messages
+type LambdaList struct {
+	Required       []*Parameter
+	Optional       []*Parameter
+	Rest           *Parameter
+	Key            []*Parameter
+	Whole          *Parameter
+	Environment    *Parameter
+	HasKey         bool
+	AllowOtherKeys bool
+	Form           types.Object
+	Slots          int
+}
+
+// MinArgs returns the number of required arguments
+func (ll *LambdaList) MinArgs() int {
+	return len(ll.Required)
+}
+
+// MaxArgs returns the number of required and optional arguments, or
+// function.Variadic if the lambda list has a &REST or &KEY parameter
+func (ll *LambdaList) MaxArgs() int {
+	if ll.Rest != nil || ll.HasKey {
+		return function.Variadic
+	}
+
+	return len(ll.Required) + len(ll.Optional)
+}
+
+// listObject returns args as object, NIL for an empty argument list
+func listObject(args *cons.Cons) types.Object {
+	if args == nil {
+		return types.NIL
+	}
+
+	return args
+}
+
+// bindValue binds value to the parameter symbol or destructures it with
+// the pattern of the parameter
+func (p *Parameter) bindValue(s *scope.Scope, value types.Object, env environment.Environment, context interface{}) error {
+	if p.Pattern != nil {
+		return p.Pattern.Bind(p.Pattern.Form, s, value, value, env, context)
+	}
+
+	s.Values[p.Slot] = value
+
+	return nil
+}
+
+// bindDefault binds the default of an unsupplied parameter
+func (p *Parameter) bindDefault(s *scope.Scope, env environment.Environment, context interface{}) error {
+	var value types.Object = types.NIL
+
+	if p.Default != nil {
+		var err error
+
+		value, err = p.Default(env, context)
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.Supplied != nil {
+		s.Values[p.SuppliedSlot] = types.NIL
+	}
+
+	return p.bindValue(s, value, env, context)
+}
+
+// bindSupplied binds a supplied argument to the parameter
+func (p *Parameter) bindSupplied(s *scope.Scope, value types.Object, env environment.Environment, context interface{}) error {
+	if p.Supplied != nil {
+		s.Values[p.SuppliedSlot] = types.T
+	}
+
+	return p.bindValue(s, value, env, context)
+}
+
+// Bind binds the list args to the parameters in s, s must be pushed as
+// current scope so default forms can refer to the parameters before them.
+// whole is bound to the &WHOLE parameter, name is only used in error
+// messages
+func (ll *LambdaList) Bind(name interface{}, s *scope.Scope, whole types.Object, args types.Object, env environment.Environment, context interface{}) error {
+	if args.Type() != types.Cons && args.Type() != types.Null {
+		return errors.Errorf(errors.TypeError, "%v expected a list, got %v", name, args)
+	}
+
+	n := 0
+	tail := args
+	for ; tail.Type() == types.Cons; tail = tail.(*cons.Cons).Cdr {
+		n++
+	}
+
+	if tail != types.NIL && ll.Rest == nil {
+		return errors.Errorf(errors.TypeError, "%v expected a proper list, got %v", name, args)
+	}
+
+	err := CheckArity(name, ll, n)
+	if err != nil {
+		return err
+	}
+
+	if ll.Whole != nil {
+		s.Values[ll.Whole.Slot] = whole
+	}
+
+	remaining := args
+
+	for _, p := range ll.Required {
+		err = p.bindValue(s, remaining.(*cons.Cons).Car, env, context)
+		if err != nil {
+			return err
+		}
+
+		remaining = remaining.(*cons.Cons).Cdr
+	}
+
+	for _, p := range ll.Optional {
+		if remaining.Type() != types.Cons {
+			err = p.bindDefault(s, env, context)
+		} else {
+			err = p.bindSupplied(s, remaining.(*cons.Cons).Car, env, context)
+			remaining = remaining.(*cons.Cons).Cdr
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if ll.Rest != nil {
+		err = ll.Rest.bindValue(s, remaining, env, context)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ll.HasKey {
+		return ll.bindKeys(name, s, remaining, env, context)
+	}
+
+	return nil
+}
+
+// bindKeys binds the keyword arguments, for keywords given more than once
+// the first argument is used
+func (ll *LambdaList) bindKeys(name interface{}, s *scope.Scope, args types.Object, env environment.Environment, context interface{}) error {
+	values := map[*symbols.Symbol]types.Object{}
+	order := []*symbols.Symbol{}
+	allowOtherKeys := ll.AllowOtherKeys
+
+	for args.Type() == types.Cons {
+		pair := args.(*cons.Cons)
+		if pair.Cdr.Type() != types.Cons {
+			return errors.Errorf(errors.ArityError, "%v expected keyword argument pairs, got an odd number of arguments", name)
+		}
+
+		keyword, ok := pair.Car.(*symbols.Symbol)
+		if !ok || !keyword.IsKeyword {
+			return errors.Errorf(errors.ArityError, "%v expected a keyword, got %v", name, pair.Car)
+		}
+
+		value := pair.Cdr.(*cons.Cons).Car
+
+		if _, ok := values[keyword]; !ok {
+			values[keyword] = value
+			order = append(order, keyword)
+
+			if keyword.Name == "ALLOW-OTHER-KEYS" && value != types.NIL {
+				allowOtherKeys = true
+			}
+		}
+
+		args = pair.Cdr.(*cons.Cons).Cdr
+	}
+
+	if !allowOtherKeys {
+		for _, keyword := range order {
+			if keyword.Name == "ALLOW-OTHER-KEYS" {
+				continue
+			}
+
+			known := false
+			for _, p := range ll.Key {
+				if p.Keyword == keyword {
+					known = true
+					break
+				}
+			}
+
+			if !known {
+				return errors.Errorf(errors.ArityError, "%v got unknown keyword argument :%v", name, keyword)
+			}
+		}
+	}
+
+	for _, p := range ll.Key {
+		value, ok := values[p.Keyword]
+		if !ok {
+			err := p.bindDefault(s, env, context)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err := p.bindSupplied(s, value, env, context)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -8,37 +8,64 @@ import (
 	"github.com/almerlucke/glisp/scope"
 	"github.com/almerlucke/glisp/types"
 	"github.com/almerlucke/glisp/types/cons"
-	"github.com/almerlucke/glisp/types/symbols"
-
-	globals "github.com/almerlucke/glisp/globals/symbols"
+	"github.com/almerlucke/glisp/types/environments"
 )
 
 // MacroFunction is like a lambda function except args are
 // not evaluated and the result of the initial evaluation (expansion)
 // is evaluated again
 type MacroFunction struct {
-	argList       []*symbols.Symbol
-	capturedScope scope.Scope
-	body          *cons.Cons
+	lambdaList    *LambdaList
+	description   *Description
+	layout        *scope.Layout
+	capturedScope *scope.Scope
+	body          environment.Closure
+	pos           *cons.Position
 }
 
-// NewMacroFunction creates a new macro function
-func NewMacroFunction(argList []*symbols.Symbol, capturedScope scope.Scope, body *cons.Cons) *MacroFunction {
+// NewMacroFunction creates a new macro function, description is nil for an
+// anonymous function
+func NewMacroFunction(lambdaList *LambdaList, description *Description, layout *scope.Layout, capturedScope *scope.Scope, body environment.Closure, pos *cons.Position) *MacroFunction {
 	return &MacroFunction{
-		argList:       argList,
+		lambdaList:    lambdaList,
+		description:   description,
+		layout:        layout,
 		capturedScope: capturedScope,
 		body:          body,
+		pos:           pos,
 	}
 }
 
+// Description returns the name and docstring of the function, nil for an
+// anonymous function
+func (fun *MacroFunction) Description() *Description {
+	return fun.description
+}
+
+// LambdaList returns the lambda list of the function
+func (fun *MacroFunction) LambdaList() *LambdaList {
+	return fun.lambdaList
+}
+
+// Position returns the position of the macro form that created the function,
+// nil if unknown
+func (fun *MacroFunction) Position() *cons.Position {
+	return fun.pos
+}
+
 // EvalArgs evaluate args
 func (fun *MacroFunction) EvalArgs() bool {
 	return false
 }
 
-// NumArgs number of arguments
-func (fun *MacroFunction) NumArgs() int {
-	return len(fun.argList)
+// MinArgs minimum number of arguments
+func (fun *MacroFunction) MinArgs() int {
+	return fun.lambdaList.MinArgs()
+}
+
+// MaxArgs maximum number of arguments
+func (fun *MacroFunction) MaxArgs() int {
+	return fun.lambdaList.MaxArgs()
 }
 
 // Type of Function
@@ -48,6 +75,10 @@ func (fun *MacroFunction) Type() types.Type {
 
 // String for Stringer
 func (fun *MacroFunction) String() string {
+	if fun.description != nil {
+		return fmt.Sprintf("macro(%v)", fun.description.Name)
+	}
+
 	return fmt.Sprintf("macro(%p)", fun)
 }
 
@@ -61,67 +92,62 @@ func (fun *MacroFunction) Equal(obj types.Object) bool {
 	return fun == obj
 }
 
-// Macro expansion
-func (fun *MacroFunction) expansion(args *cons.Cons, env environment.Environment, context interface{}) (result types.Object, err error) {
-	// First push captured scope
-	env.PushScope(fun.capturedScope)
+// Expand returns the expansion of the macro call form, form is bound to the
+// &WHOLE parameter and the arguments of the macro are the rest of the form
+func (fun *MacroFunction) Expand(form *cons.Cons, env environment.Environment, context interface{}) (result types.Object, err error) {
+	s := scope.New(fun.layout, fun.capturedScope)
+
+	// The environment of the macro call is the scope active before the
+	// local scope is pushed
+	if fun.lambdaList.Environment != nil {
+		s.Values[fun.lambdaList.Environment.Slot] = environments.New(env)
+	}
 
-	// Push local scope for bound input arguments
-	env.PushScope(nil)
+	// Push local scope for input arguments, default forms are evaluated in
+	// the local scope
+	env.PushScope(s)
 
 	// Push call
 	env.PushDepthContext("CallDepth")
 
 	defer func() {
-		// Pop scopes
-		env.PopScope()
+		// Pop scope
 		env.PopScope()
 
 		// Pop call
 		env.PopDepthContext("CallDepth")
 
 		if r := recover(); r != nil {
-			// Return value
-			returnContext, ok := r.(*function.ReturnContext)
-			if ok {
-				// Return the evaluation of the macro expansion
-				result = returnContext.Object
-			} else {
-				// Continue to panic
-				panic(r)
-			}
+			// Return the evaluation of the macro expansion
+			result = function.Landed(r, function.ReturnPoint)
 		}
 	}()
 
-	// Bind arguments
-	for _, sym := range fun.argList {
-		if sym.Reserved {
-			return nil, fmt.Errorf("can't bind to reserved symbol %v", sym)
-		}
-
-		env.AddBinding(sym, args.Car)
-
-		if args.Cdr.Type() == types.Cons {
-			args = args.Cdr.(*cons.Cons)
-		} else {
-			args = nil
-		}
+	err = checkCallDepth(env)
+	if err != nil {
+		return nil, err
 	}
 
-	// Add binding for &rest symbol with remaining args
-	if args != nil {
-		env.AddBinding(globals.AndRestSymbol, args)
-	} else {
-		env.AddBinding(globals.AndRestSymbol, types.NIL)
+	// Bind arguments
+	err = fun.lambdaList.Bind(Name(fun, nil), s, form, form.Cdr, env, context)
+	if err != nil {
+		return nil, err
 	}
 
 	// Expand macro body
-	if fun.body != nil {
-		err = fun.body.Iter(func(obj types.Object, index interface{}) (bool, error) {
-			result, err = env.Eval(obj, context)
-			return false, err
-		})
+	result, err = fun.body(env, context)
+	if err != nil {
+		return nil, err
+	}
+
+	// The expansion must be a form, so resolve pending tail calls
+	for {
+		tailCall, ok := result.(*function.TailCall)
+		if !ok {
+			break
+		}
 
+		result, err = tailCall.Function.Eval(tailCall.Args, env, context)
 		if err != nil {
 			return nil, err
 		}
@@ -130,13 +156,27 @@ func (fun *MacroFunction) expansion(args *cons.Cons, env environment.Environment
 	return result, nil
 }
 
-// Eval lambda function evaluation
-func (fun *MacroFunction) Eval(args *cons.Cons, env environment.Environment, context interface{}) (result types.Object, err error) {
-	result, err = fun.expansion(args, env, context)
+// EvalForm expands and evaluates the macro call form, the expansion of the
+// form is evaluated in place of the form. Calls compiled by the compiler
+// expand a call form only once, EvalForm expands the form on every call
+func (fun *MacroFunction) EvalForm(form *cons.Cons, env environment.Environment, context interface{}) (result types.Object, err error) {
+	// The expansion can call the macro again, so count the evaluation of the
+	// expansion towards the call depth
+	env.PushDepthContext("ExpansionDepth")
+	defer env.PopDepthContext("ExpansionDepth")
+
+	result, err = fun.Expand(form, env, context)
 	if err != nil {
 		return nil, err
 	}
 
-	// Return the evaluation of the macro expansion
-	return env.Eval(result, context)
+	// Return the evaluation of the macro expansion, the expansion takes the
+	// place of the macro call so it is evaluated in tail position
+	return env.EvalTail(result, context)
+}
+
+// Eval lambda function evaluation, without a call form the macro itself
+// takes the place of the macro name in the form bound to &WHOLE
+func (fun *MacroFunction) Eval(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	return fun.EvalForm(&cons.Cons{Car: fun, Cdr: listObject(args)}, env, context)
 }
@@ -0,0 +1,81 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/almerlucke/glisp/interfaces/environment"
+	"github.com/almerlucke/glisp/types"
+	"github.com/almerlucke/glisp/types/cons"
+)
+
+// SpecialFormCompiler compiles the unevaluated arguments of a special form
+type SpecialFormCompiler func(*cons.Cons, environment.Compiler) (environment.Closure, error)
+
+// SpecialForm is a builtin function that is compiled instead of evaluated,
+// its arguments are never evaluated before the call
+type SpecialForm struct {
+	compiler SpecialFormCompiler
+	minArgs  int
+	maxArgs  int
+}
+
+// NewSpecialForm creates a new special form, maxArgs is function.Variadic
+// if the special form accepts any number of arguments
+func NewSpecialForm(compiler SpecialFormCompiler, minArgs int, maxArgs int) *SpecialForm {
+	return &SpecialForm{
+		compiler: compiler,
+		minArgs:  minArgs,
+		maxArgs:  maxArgs,
+	}
+}
+
+// MinArgs minimum number of arguments
+func (fun *SpecialForm) MinArgs() int {
+	return fun.minArgs
+}
+
+// MaxArgs maximum number of arguments
+func (fun *SpecialForm) MaxArgs() int {
+	return fun.maxArgs
+}
+
+// EvalArgs evaluate arguments before calling eval
+func (fun *SpecialForm) EvalArgs() bool {
+	return false
+}
+
+// Type of Function
+func (fun *SpecialForm) Type() types.Type {
+	return types.Function
+}
+
+// Compile the arguments of the special form
+func (fun *SpecialForm) Compile(args *cons.Cons, compiler environment.Compiler) (environment.Closure, error) {
+	return fun.compiler(args, compiler)
+}
+
+// Eval compiles and runs the special form, this only happens when the
+// special form could not be resolved at compile time
+func (fun *SpecialForm) Eval(args *cons.Cons, env environment.Environment, context interface{}) (types.Object, error) {
+	closure, err := fun.compiler(args, env.NewCompiler())
+	if err != nil {
+		return nil, err
+	}
+
+	return closure(env, context)
+}
+
+// String for Stringer
+func (fun *SpecialForm) String() string {
+	return fmt.Sprintf("special-form(%p)", fun)
+}
+
+// Eql obj
+func (fun *SpecialForm) Eql(obj types.Object) bool {
+	return fun == obj
+}
+
+// Equal obj
+func (fun *SpecialForm) Equal(obj types.Object) bool {
+	return fun == obj
+}
@@ -14,6 +14,15 @@ type Symbol struct {
 	Value     types.Object
 	Interned  bool
 	IsKeyword bool
+	// Special symbols are dynamic variables declared with DEFVAR or
+	// DEFPARAMETER, they are never bound lexically. The flag is set after
+	// the symbol is read so it is not hashed, a symbol used as dictionary
+	// key must keep its hash
+	Special bool `hash:"ignore"`
+	// Alias is the symbol this symbol was renamed from by a hygienic macro
+	// expansion, an unbound renamed symbol refers to the global binding of
+	// its alias. Like Special it is not hashed
+	Alias *Symbol `hash:"ignore"`
 }
 
 // Type Symbol
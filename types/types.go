@@ -30,6 +30,10 @@ const (
 	Array
 	// Namespace object type
 	Namespace
+	// Condition object type
+	Condition
+	// Environment object type
+	Environment
 )
 
 // Object interface, every Lisp object must implement these methods